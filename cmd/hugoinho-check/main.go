@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/mdw-tools/hugoinho/core"
+	"github.com/mdw-tools/hugoinho/io"
+)
+
+var Version = "dev"
+
+func main() {
+	runner := core.NewCheckRunner(
+		Version,
+		os.Args[1:],
+		io.Disk{},
+		time.Now,
+		os.Stderr,
+	)
+	os.Exit(runner.Run())
+}
@@ -1,13 +1,23 @@
 package main
 
 import (
-	"log"
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"path"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
+	"github.com/mdw-tools/hugoinho/contracts"
 	"github.com/mdw-tools/hugoinho/core"
 	"github.com/mdw-tools/hugoinho/io"
 )
@@ -16,39 +26,137 @@ var Version = "dev"
 
 func main() {
 	disk := io.Disk{}
-	logger := log.New(os.Stderr, "", log.Lshortfile)
-	args := os.Args[1:]
+	address, args, err := core.ExtractFlag(os.Args[1:], "listen", "localhost:7070")
+	if err != nil {
+		fatal(slog.Default(), "invalid configuration", "error", err)
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		fatal(slog.Default(), "invalid -listen address", "address", address, "error", err)
+	}
 	config, err := core.NewCLIParser(Version, args).Parse()
 	if err != nil {
-		logger.Fatal(err)
+		fatal(slog.Default(), "invalid configuration", "error", err)
 	}
+	logger := core.NewLogger(os.Stderr, config.LogFormat, config.LogLevel)
 
 	var lock sync.Mutex
 
-	http.HandleFunc("/", func(response http.ResponseWriter, request *http.Request) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(response http.ResponseWriter, request *http.Request) {
 		lock.Lock()
 		defer lock.Unlock()
 
-		err := os.RemoveAll(config.TargetRoot)
-		if err != nil {
-			http.Error(response, "Failed to remove target directory.", http.StatusInternalServerError)
-			return
-		}
-
-		runner := core.NewPipelineRunner(Version, args, disk, time.Now, logger)
+		memory := io.NewMemory()
+		files := overlay{source: disk, target: memory, persisted: []string{config.ReportPath, config.TracePath}}
+		runner := core.NewPipelineRunner(Version, args, files, time.Now, os.Stderr)
 		errCount := runner.Run()
 		if errCount > 0 {
 			http.Error(response, "Failed to generate site.", http.StatusInternalServerError)
 			return
 		}
 
-		http.ServeFile(response, request, filepath.Join(config.TargetRoot, request.URL.Path))
+		serve(response, request, memory, filepath.Join(config.TargetRoot, request.URL.Path),
+			filepath.Join(config.TargetRoot, strings.TrimPrefix(contracts.NotFoundSlug, "/")))
 	})
 
-	address := "localhost:7070"
-	logger.Println("Open browser to:", address)
-	err = http.ListenAndServe(address, nil)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{Addr: address, Handler: mux}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		<-ctx.Done()
+		stop() // so that another Ctrl+C (during the shutdown) force-quits
+		logger.Info("shutting down (waiting for in-progress builds to finish)")
+		shutdown, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	logger.Info("open browser", "url", "http://"+address)
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fatal(logger, "server failed", "error", err)
+	}
+	<-stopped
+}
+
+func fatal(logger *slog.Logger, message string, args ...any) {
+	logger.Error(message, args...)
+	os.Exit(1)
+}
+
+// overlay reads content and templates from one file system while
+// writing rendered output to another (from which rendered output can be
+// read back, as the link checker does). The persisted files (the -report
+// and -trace) are written to the source instead, so they outlive the build.
+type overlay struct {
+	source    contracts.FileSystem
+	target    contracts.FileSystem
+	persisted []string
+}
+
+func (this overlay) ReadFile(path string) ([]byte, error) {
+	content, err := this.target.ReadFile(path)
+	if err == nil {
+		return content, nil
+	}
+	return this.source.ReadFile(path)
+}
+func (this overlay) WriteFile(path string, content []byte, perm os.FileMode) error {
+	if path != "" && slices.Contains(this.persisted, path) {
+		return this.source.WriteFile(path, content, perm)
+	}
+	return this.target.WriteFile(path, content, perm)
+}
+func (this overlay) MkdirAll(path string, perm os.FileMode) error {
+	return this.target.MkdirAll(path, perm)
+}
+func (this overlay) RemoveAll(path string) error {
+	return this.target.RemoveAll(path)
+}
+func (this overlay) Rename(oldPath, newPath string) error {
+	return this.target.Rename(oldPath, newPath)
+}
+func (this overlay) Walk(root string) chan contracts.FileSystemEntry {
+	return this.source.Walk(root)
+}
+
+// serve writes the rendered file found at name (or the index.html within
+// it, for directories). The Content-Type is derived from the extension.
+// Unknown paths get the rendered notFound page (if any) with a 404 status.
+func serve(response http.ResponseWriter, request *http.Request, memory *io.Memory, name, notFound string) {
+	info, err := memory.Stat(name)
+	if err != nil {
+		serveNotFound(response, request, memory, notFound)
+		return
+	}
+	if info.IsDir() {
+		if !strings.HasSuffix(request.URL.Path, "/") {
+			http.Redirect(response, request, path.Base(request.URL.Path)+"/", http.StatusMovedPermanently)
+			return
+		}
+		name = filepath.Join(name, "index.html")
+		if info, err = memory.Stat(name); err != nil {
+			serveNotFound(response, request, memory, notFound)
+			return
+		}
+	}
+	content, err := memory.ReadFile(name)
+	if err != nil {
+		http.Error(response, "Failed to read rendered file.", http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(response, request, name, info.ModTime(), bytes.NewReader(content))
+}
+func serveNotFound(response http.ResponseWriter, request *http.Request, memory *io.Memory, notFound string) {
+	content, err := memory.ReadFile(notFound)
 	if err != nil {
-		logger.Fatal(err)
+		http.NotFound(response, request)
+		return
 	}
+	response.Header().Set("Content-Type", "text/html; charset=utf-8")
+	response.WriteHeader(http.StatusNotFound)
+	_, _ = response.Write(content)
 }
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"log"
 	"os"
 	"time"
 
@@ -17,7 +16,7 @@ func main() {
 		os.Args[1:],
 		io.Disk{},
 		time.Now,
-		log.New(os.Stderr, "", log.Lshortfile),
+		os.Stderr,
 	)
 	os.Exit(runner.Run())
 }
@@ -4,11 +4,18 @@ import "time"
 
 type Article struct {
 	Error    error
+	Warnings []error
 	Source   ArticleSource
 	Metadata ArticleMetadata
 	Content  ArticleContent
 }
 
+// Warn records a soft problem with the article, which (unlike Error)
+// doesn't keep it from being published.
+func (this *Article) Warn(warning error) {
+	this.Warnings = append(this.Warnings, warning)
+}
+
 type ArticleSource struct {
 	Path string
 	Data string
@@ -21,6 +28,41 @@ type ArticleMetadata struct {
 	Intro  string
 	Topics []string
 	Date   time.Time
+
+	// Series names the multi-part series (if any) that the article is
+	// part number SeriesPart of.
+	Series     string
+	SeriesPart int
+
+	// Kind is KindArticle (the default) or KindPage: a standalone page
+	// (e.g. /about/) left out of the homepage, archives and topics. The
+	// KindNotFound page is also a standalone page, served (by most static
+	// hosts) for unknown paths. It has no slug of its own: its slug is
+	// always NotFoundSlug.
+	Kind string
+
+	// Aliases are old slugs, each of which redirects to Slug.
+	Aliases []string
+
+	// Pinned articles are listed above the most recent ones on the
+	// homepage, those of lower Weight first.
+	Pinned bool
+	Weight int
+}
+
+const (
+	KindArticle  = "article"
+	KindPage     = "page"
+	KindNotFound = "not-found"
+
+	NotFoundSlug = "/404.html"
+)
+
+func (this ArticleMetadata) IsPage() bool {
+	return this.Kind == KindPage || this.Kind == KindNotFound
+}
+func (this ArticleMetadata) IsNotFound() bool {
+	return this.Kind == KindNotFound
 }
 
 const METADATA_CONTENT_DIVIDER = "\n+++\n"
@@ -29,8 +71,3 @@ type ArticleContent struct {
 	Original  string
 	Converted string
 }
-
-type (
-	Filter func(*Article) bool
-	Sorter func(i, j RenderedArticleSummary) int
-)
@@ -1,10 +1,29 @@
 package contracts
 
+import "log/slog"
+
 type Config struct {
-	TemplateDir string
-	ContentRoot string
-	TargetRoot  string
-	BasePath    string
-	BuildDrafts bool
-	BuildFuture bool
+	TemplateDir    string
+	ContentRoot    string
+	TargetRoot     string
+	BasePath       string
+	BuildDrafts    bool
+	BuildFuture    bool
+	BuildInPlace   bool
+	Prune          bool
+	PruneDryRun    bool
+	DryRun         bool
+	FailOnWarnings bool
+	TopicNeighbors bool
+	RelatedCount   int
+	HomeRecent     int
+	HomeTopics     int
+	TopicMinimum   int
+	TopicOrder     string
+	AllowedLinks   []string
+	ReportPath     string
+	ReportFormat   string
+	TracePath      string
+	LogFormat      string
+	LogLevel       slog.Level
 }
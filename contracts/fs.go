@@ -9,6 +9,8 @@ type FileSystem interface {
 	ReadFile
 	WriteFile
 	MkdirAll
+	RemoveAll
+	Rename
 	Walk
 }
 
@@ -25,6 +27,14 @@ type (
 		MkdirAll(path string, perm os.FileMode) error
 	}
 
+	RemoveAll interface {
+		RemoveAll(path string) error
+	}
+
+	Rename interface {
+		Rename(oldPath, newPath string) error
+	}
+
 	Walk interface {
 		Walk(root string) chan FileSystemEntry
 	}
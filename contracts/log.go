@@ -1,7 +1,10 @@
 package contracts
 
+// Logger is a leveled, structured logger (satisfied by *slog.Logger).
+// Arguments following the message are alternating keys and values.
 type Logger interface {
-	Print(...any)
-	Printf(string, ...any)
-	Println(...any)
+	Debug(message string, args ...any)
+	Info(message string, args ...any)
+	Warn(message string, args ...any)
+	Error(message string, args ...any)
 }
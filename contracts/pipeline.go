@@ -1,6 +1,9 @@
 package contracts
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 type Handler interface {
 	Handle(*Article)
@@ -10,4 +13,22 @@ type Finalizer interface {
 	Finalize() error
 }
 
+// Warner is implemented by handlers that can only spot some soft problems
+// once every article has been seen. Warnings is called once the pipeline
+// has drained.
+type Warner interface {
+	Warnings() []error
+}
+
 var ErrDroppedArticle = errors.New("dropped article")
+
+// Observer is told how long each pipeline stage spent handling each
+// article. Time spent in a stage's Finalize is reported with a nil article.
+type Observer interface {
+	Observe(stage Stage, article *Article, started, finished time.Time)
+}
+
+type Stage struct {
+	Index int
+	Name  string
+}
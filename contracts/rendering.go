@@ -14,8 +14,17 @@ const (
 	ArchivesTemplateName = "archives.tmpl"
 	ArticleTemplateName  = "article.tmpl"
 	TopicsTemplateName   = "topics.tmpl"
+	SeriesTemplateName   = "series.tmpl"
+	PageTemplateName     = "page.tmpl"
+	NotFoundTemplateName = "404.tmpl"
 )
 
+// TemplateSet is implemented by renderers that can tell whether an
+// optional template (like NotFoundTemplateName) was provided.
+type TemplateSet interface {
+	HasTemplate(name string) bool
+}
+
 var (
 	ErrUnsupportedRenderingType = errors.New("unsupported rendering type")
 	ErrRenderingFailure         = errors.New("failed to render template")
@@ -23,8 +32,9 @@ var (
 
 type (
 	RenderedHomePage struct {
-		ProminentTopics []string
-		Pages           []RenderedArticleSummary
+		ProminentTopics []RenderedTopic
+		Pinned          []RenderedArticleSummary
+		Recent          []RenderedArticleSummary
 	}
 
 	RenderedArchivesPage struct {
@@ -38,6 +48,50 @@ type (
 		Date    time.Time
 		Topics  []string
 		Content string
+
+		// Backlinks are the published articles that link here.
+		Backlinks []RenderedArticleSummary
+
+		// Previous and Next are the published articles just before and
+		// after this one (by date), if any.
+		Previous *RenderedArticleSummary
+		Next     *RenderedArticleSummary
+
+		// Related are the published articles with the most topics in
+		// common with this one.
+		Related []RenderedArticleSummary
+
+		// Series lists every published part of the article's series
+		// (nil when it isn't part of one).
+		Series *RenderedSeries
+	}
+
+	// RenderedPage is a standalone page (kind: page). It is rendered with
+	// PageTemplateName when there is such a template, and otherwise with
+	// ArticleTemplateName. Pages have no previous/next, related or series.
+	RenderedPage struct {
+		RenderedArticle
+	}
+
+	// RenderedNotFoundPage is the page written to /404.html: either the
+	// article of kind not-found or (without one) just NotFoundTemplateName.
+	// It is rendered with the first of NotFoundTemplateName,
+	// PageTemplateName and ArticleTemplateName that exists.
+	RenderedNotFoundPage struct {
+		RenderedArticle
+	}
+
+	// RenderedSeries is also the data for each series' index page.
+	RenderedSeries struct {
+		Name  string
+		Slug  string
+		Parts []RenderedSeriesPart
+	}
+
+	RenderedSeriesPart struct {
+		RenderedArticleSummary
+		Part    int
+		Current bool
 	}
 
 	RenderedArticleSummary struct {
@@ -55,6 +109,13 @@ type (
 
 	RenderedTopicListing struct {
 		Topic    string
+		Count    int
 		Articles []RenderedArticleSummary
 	}
+
+	// RenderedTopic is a topic and the number of articles that mention it.
+	RenderedTopic struct {
+		Topic string
+		Count int
+	}
 )
@@ -2,55 +2,39 @@ package core
 
 import (
 	"path/filepath"
-	"slices"
 
 	"github.com/mdw-tools/hugoinho/contracts"
 )
 
+// ArchivesRenderingHandler lists every published article (most recent
+// first) from the site index, once every article has streamed past.
 type ArchivesRenderingHandler struct {
-	pages    []contracts.RenderedArticleSummary
-	filter   contracts.Filter
-	sorter   contracts.Sorter
+	site     *SiteIndex
 	renderer contracts.Renderer
 	disk     RenderingFileSystem
 	output   string
 }
 
 func NewArchivesRenderingHandler(
-	filter contracts.Filter,
-	sorter contracts.Sorter,
+	site *SiteIndex,
 	renderer contracts.Renderer,
 	disk RenderingFileSystem,
 	output string,
 ) *ArchivesRenderingHandler {
 	return &ArchivesRenderingHandler{
-		filter:   filter,
-		sorter:   sorter,
+		site:     site,
 		renderer: renderer,
 		disk:     disk,
 		output:   output,
 	}
 }
-func (this *ArchivesRenderingHandler) Handle(article *contracts.Article) {
-	if !this.filter(article) {
-		return
-	}
-	this.pages = append(this.pages, contracts.RenderedArticleSummary{
-		Slug:   article.Metadata.Slug,
-		Title:  article.Metadata.Title,
-		Intro:  article.Metadata.Intro,
-		Date:   article.Metadata.Date,
-		Topics: article.Metadata.Topics,
-		Draft:  article.Metadata.Draft,
-	})
-}
+func (this *ArchivesRenderingHandler) Handle(*contracts.Article) {}
 func (this *ArchivesRenderingHandler) Finalize() error {
-	if len(this.pages) == 0 {
+	pages := this.site.Articles()
+	if len(pages) == 0 {
 		return nil
 	}
-	rendered, err := this.renderer.Render(contracts.RenderedArchivesPage{
-		Pages: slices.SortedStableFunc(slices.Values(this.pages), this.sorter),
-	})
+	rendered, err := this.renderer.Render(contracts.RenderedArchivesPage{Pages: pages})
 	if err != nil {
 		return err
 	}
@@ -2,7 +2,6 @@ package core
 
 import (
 	"errors"
-	"strings"
 	"testing"
 
 	"github.com/mdw-go/testing/v2/better"
@@ -19,25 +18,20 @@ type ArchivesRenderingHandlerSuite struct {
 	*suite.T
 
 	handler  *ArchivesRenderingHandler
+	site     *SiteIndex
 	renderer *FakeRenderer
-	disk     *InMemoryFileSystem
+	disk     *FakeFileSystem
 }
 
-func (this *ArchivesRenderingHandlerSuite) filter(article *contracts.Article) bool {
-	return article.Metadata.Title < "C"
-}
-func (this *ArchivesRenderingHandlerSuite) sorter(i, j contracts.RenderedArticleSummary) int {
-	return strings.Compare(i.Title, j.Title)
-}
 func (this *ArchivesRenderingHandlerSuite) assertHandledArticlesRendered() {
 	this.So(this.renderer.rendered, should.Equal, contracts.RenderedArchivesPage{
 		Pages: []contracts.RenderedArticleSummary{
 			{
-				Slug:   "/a",
-				Title:  "A",
-				Intro:  "aa",
-				Date:   Date(2023, 7, 7),
-				Topics: []string{"topic-a"},
+				Slug:   "/c",
+				Title:  "C",
+				Intro:  "cc",
+				Date:   Date(2023, 7, 9),
+				Topics: []string{"topic-c"},
 				Draft:  false,
 			},
 			{
@@ -56,26 +50,35 @@ func (this *ArchivesRenderingHandlerSuite) assertHandledArticlesRendered() {
 				Topics: []string{"topic-b"},
 				Draft:  true,
 			},
+			{
+				Slug:   "/a",
+				Title:  "A",
+				Intro:  "aa",
+				Date:   Date(2023, 7, 7),
+				Topics: []string{"topic-a"},
+				Draft:  false,
+			},
 		},
 	})
 }
 func (this *ArchivesRenderingHandlerSuite) Setup() {
 	this.renderer = NewFakeRenderer()
-	this.disk = NewInMemoryFileSystem()
-	this.handler = NewArchivesRenderingHandler(this.filter, this.sorter, this.renderer, this.disk, "output/folder")
+	this.disk = NewFakeFileSystem()
+	this.site = NewSiteIndex()
+	this.handler = NewArchivesRenderingHandler(this.site, this.renderer, this.disk, "output/folder")
 }
 func (this *ArchivesRenderingHandlerSuite) handleAndFinalize() error {
-	this.handler.Handle(articleA)
-	this.handler.Handle(articleB)
-	this.handler.Handle(articleB2)
-	this.handler.Handle(articleC)
+	for _, article := range []*contracts.Article{articleA, articleB, articleB2, articleC, standalonePage} {
+		this.site.Add(*article)
+		this.handler.Handle(article)
+	}
 	return this.handler.Finalize()
 }
 func (this *ArchivesRenderingHandlerSuite) TestNoArticles_NothingToRender() {
-	this.handler.Handle(articleC) // will be filtered out
+	this.site.Add(*standalonePage) // pages are left out
 	err := this.handler.Finalize()
 	this.So(err, should.BeNil)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 func (this *ArchivesRenderingHandlerSuite) TestFileTemplateRenderedAndWrittenToDisk() {
 	this.renderer.result = "RENDERED"
@@ -84,10 +87,9 @@ func (this *ArchivesRenderingHandlerSuite) TestFileTemplateRenderedAndWrittenToD
 
 	this.So(err, should.BeNil)
 	this.assertHandledArticlesRendered()
-	this.So(this.disk.Files, should.Contain, "output/folder")
-	this.So(this.disk.Files, better.Contain, "output/folder/archives/index.html")
-	file := this.disk.Files["output/folder/archives/index.html"]
-	this.So(file.Content(), should.Equal, "RENDERED")
+	this.So(this.disk.Paths(), should.Contain, "output/folder")
+	this.So(this.disk.Paths(), better.Contain, "output/folder/archives/index.html")
+	this.So(this.disk.Content("output/folder/archives/index.html"), should.Equal, "RENDERED")
 }
 func (this *ArchivesRenderingHandlerSuite) TestRenderErrorReturned() {
 	renderErr := errors.New("boink")
@@ -96,7 +98,7 @@ func (this *ArchivesRenderingHandlerSuite) TestRenderErrorReturned() {
 	err := this.handleAndFinalize()
 
 	this.So(err, should.WrapError, renderErr)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 func (this *ArchivesRenderingHandlerSuite) TestMkdirAllErrorReturned() {
 	this.renderer.result = "RENDERED"
@@ -106,7 +108,7 @@ func (this *ArchivesRenderingHandlerSuite) TestMkdirAllErrorReturned() {
 	err := this.handleAndFinalize()
 
 	this.So(err, should.WrapError, mkdirErr)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 func (this *ArchivesRenderingHandlerSuite) TestWriteFileErrorReturned() {
 	this.renderer.result = "RENDERED"
@@ -116,5 +118,5 @@ func (this *ArchivesRenderingHandlerSuite) TestWriteFileErrorReturned() {
 	err := this.handleAndFinalize()
 
 	this.So(err, should.WrapError, writeFileErr)
-	this.So(this.disk.Files, should.NOT.Contain, "output/folder/archives.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "output/folder/archives.html")
 }
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// ArticleIndex knows every article that made it through the pipeline's
+// barrier (published or not), keyed by source path and by slug.
+type ArticleIndex struct {
+	articles map[string]contracts.Article
+	bySlug   map[string]contracts.Article
+}
+
+func NewArticleIndex() *ArticleIndex {
+	return &ArticleIndex{
+		articles: make(map[string]contracts.Article),
+		bySlug:   make(map[string]contracts.Article),
+	}
+}
+
+func (this *ArticleIndex) Add(article contracts.Article) {
+	if article.Source.Path == "" {
+		return
+	}
+	this.articles[filepath.ToSlash(filepath.Clean(article.Source.Path))] = article
+	key := slugKey(article.Metadata.Slug)
+	if key == "" {
+		return
+	}
+	if existing, found := this.bySlug[key]; !found || existing.Error != nil {
+		this.bySlug[key] = article
+	}
+}
+func slugKey(slug string) string {
+	return strings.Trim(slug, "/")
+}
+
+// Resolve maps a link found in the article at from which targets another
+// content file (a relative path ending in .md) to that article's slug,
+// keeping any query and fragment. Wiki links (wiki:slug) are resolved
+// the same way, by slug. Other links are returned unchanged.
+func (this *ArticleIndex) Resolve(from, destination string) (string, error) {
+	parsed, err := url.Parse(destination)
+	if err != nil {
+		return destination, nil
+	}
+	var target contracts.Article
+	var found bool
+	switch {
+	case parsed.Scheme+":" == wikiLinkScheme:
+		slug, _ := url.PathUnescape(parsed.Opaque)
+		target, found = this.bySlug[slugKey(slug+parsed.Path)]
+	case parsed.Scheme != "" || parsed.Host != "" || parsed.Opaque != "":
+		return destination, nil
+	case parsed.Path == "" || strings.HasPrefix(parsed.Path, "/") || !strings.EqualFold(path.Ext(parsed.Path), ".md"):
+		return destination, nil
+	default:
+		target, found = this.articles[path.Join(path.Dir(filepath.ToSlash(from)), parsed.Path)]
+	}
+	switch {
+	case !found:
+		return "", fmt.Errorf("%w: [%s] (no such article)", errUnresolvedArticleLink, destination)
+	case errors.Is(target.Error, contracts.ErrDroppedArticle):
+		return "", fmt.Errorf("%w: [%s] (article was dropped)", errUnresolvedArticleLink, destination)
+	case target.Error != nil:
+		return "", fmt.Errorf("%w: [%s] (article has errors)", errUnresolvedArticleLink, destination)
+	}
+	resolved := url.URL{Path: target.Metadata.Slug, RawQuery: parsed.RawQuery, Fragment: parsed.Fragment}
+	return resolved.String(), nil
+}
+
+var errUnresolvedArticleLink = errors.New("unresolved article link")
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestArticleIndexFixture(t *testing.T) {
+	suite.Run(&ArticleIndexFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type ArticleIndexFixture struct {
+	*suite.T
+
+	index *ArticleIndex
+}
+
+func (this *ArticleIndexFixture) Setup() {
+	this.index = NewArticleIndex()
+	this.index.Add(this.article("content/series/part-one.md", "/part-one/", nil))
+	this.index.Add(this.article("content/series/part-two.md", "/part-two/", nil))
+	this.index.Add(this.article("content/other.md", "/other/", nil))
+	this.index.Add(this.article("content/duplicate.md", "/other/", errRepeatedMetadataSlug))
+	this.index.Add(this.article("content/draft.md", "/draft/", contracts.ErrDroppedArticle))
+	this.index.Add(this.article("content/broken.md", "", errBlankMetadataSlug))
+	this.index.Add(contracts.Article{Error: errMissingMetadata})
+}
+
+func (this *ArticleIndexFixture) article(path, slug string, err error) contracts.Article {
+	return contracts.Article{
+		Error:    err,
+		Source:   contracts.ArticleSource{Path: path},
+		Metadata: contracts.ArticleMetadata{Slug: slug},
+	}
+}
+
+func (this *ArticleIndexFixture) resolve(destination string) (string, error) {
+	return this.index.Resolve("content/series/part-one.md", destination)
+}
+
+func (this *ArticleIndexFixture) TestRelativeLinksResolvedToSlugs() {
+	for destination, expected := range map[string]string{
+		"part-two.md":            "/part-two/",
+		"./part-two.md":          "/part-two/",
+		"../other.md":            "/other/",
+		"../other.md#section":    "/other/#section",
+		"../other.md?x=1#detail": "/other/?x=1#detail",
+	} {
+		resolved, err := this.resolve(destination)
+		this.So(err, should.BeNil)
+		this.So(resolved, should.Equal, expected)
+	}
+}
+
+func (this *ArticleIndexFixture) TestOtherLinksUnchanged() {
+	for _, destination := range []string{
+		"https://example.com/readme.md",
+		"/already/a/slug/",
+		"/content/other.md",
+		"picture.png",
+		"#section",
+		"mailto:me@example.com",
+	} {
+		resolved, err := this.resolve(destination)
+		this.So(err, should.BeNil)
+		this.So(resolved, should.Equal, destination)
+	}
+}
+
+func (this *ArticleIndexFixture) TestMissingArticle_Err() {
+	_, err := this.resolve("part-three.md")
+
+	this.So(err, should.WrapError, errUnresolvedArticleLink)
+	this.So(err.Error(), should.Contain, "(no such article)")
+}
+
+func (this *ArticleIndexFixture) TestDroppedArticle_Err() {
+	_, err := this.resolve("../draft.md")
+
+	this.So(err, should.WrapError, errUnresolvedArticleLink)
+	this.So(err.Error(), should.Contain, "(article was dropped)")
+}
+
+func (this *ArticleIndexFixture) TestFailedArticle_Err() {
+	_, err := this.resolve("../broken.md")
+
+	this.So(err, should.WrapError, errUnresolvedArticleLink)
+	this.So(err.Error(), should.Contain, "(article has errors)")
+}
+
+func (this *ArticleIndexFixture) TestWikiLinksResolvedBySlug() {
+	for destination, expected := range map[string]string{
+		"wiki:other":          "/other/",
+		"wiki:/other/":        "/other/",
+		"wiki:part-two#intro": "/part-two/#intro",
+	} {
+		resolved, err := this.resolve(destination)
+		this.So(err, should.BeNil)
+		this.So(resolved, should.Equal, expected)
+	}
+}
+
+func (this *ArticleIndexFixture) TestWikiLinkToMissingArticle_Err() {
+	_, err := this.resolve("wiki:nowhere")
+	this.So(err, should.WrapError, errUnresolvedArticleLink)
+	this.So(err.Error(), should.Contain, "(no such article)")
+
+	_, err = this.resolve("wiki:draft")
+	this.So(err, should.WrapError, errUnresolvedArticleLink)
+	this.So(err.Error(), should.Contain, "(article was dropped)")
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/mdw-tools/hugoinho/contracts"
@@ -14,34 +15,57 @@ type RenderingFileSystem interface {
 type ArticleRenderingHandler struct {
 	disk     RenderingFileSystem
 	renderer contracts.Renderer
+	site     *SiteIndex
+	byTopic  bool
+	related  int
 	output   string
 }
 
 func NewArticleRenderingHandler(
 	disk RenderingFileSystem,
 	renderer contracts.Renderer,
+	site *SiteIndex,
+	byTopic bool,
+	related int,
 	output string,
 ) *ArticleRenderingHandler {
 	return &ArticleRenderingHandler{
 		disk:     disk,
 		renderer: renderer,
+		site:     site,
+		byTopic:  byTopic,
+		related:  related,
 		output:   output,
 	}
 }
 
 func (this *ArticleRenderingHandler) Handle(article *contracts.Article) {
+	if article.Metadata.IsNotFound() {
+		return // see NotFoundPageRenderingHandler
+	}
 	data := contracts.RenderedArticle{
-		Slug:    article.Metadata.Slug,
-		Title:   article.Metadata.Title,
-		Intro:   article.Metadata.Intro,
-		Date:    article.Metadata.Date,
-		Topics:  article.Metadata.Topics,
-		Content: article.Content.Converted,
+		Slug:      article.Metadata.Slug,
+		Title:     article.Metadata.Title,
+		Intro:     article.Metadata.Intro,
+		Date:      article.Metadata.Date,
+		Topics:    article.Metadata.Topics,
+		Content:   article.Content.Converted,
+		Backlinks: this.site.Backlinks(article.Metadata.Slug),
+		Related:   this.site.Related(article.Metadata.Slug, this.related),
 	}
 
-	rendered, err := this.renderer.Render(data)
+	data.Previous, data.Next = this.site.Neighbors(article.Metadata.Slug, this.byTopic)
+	data.Series = this.series(article)
+
+	// The site index leaves pages out of previous/next, related and series.
+	var page any = data
+	if article.Metadata.IsPage() {
+		page = contracts.RenderedPage{RenderedArticle: data}
+	}
+
+	rendered, err := this.renderer.Render(page)
 	if err != nil {
-		article.Error = err
+		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, err)
 		return
 	}
 
@@ -58,3 +82,15 @@ func (this *ArticleRenderingHandler) Handle(article *contracts.Article) {
 		return
 	}
 }
+
+// series lists the parts of the article's series, marking its own.
+func (this *ArticleRenderingHandler) series(article *contracts.Article) *contracts.RenderedSeries {
+	series, found := this.site.Series(article.Metadata.Series)
+	if !found {
+		return nil
+	}
+	for i := range series.Parts {
+		series.Parts[i].Current = series.Parts[i].Slug == article.Metadata.Slug
+	}
+	return &series
+}
@@ -17,14 +17,20 @@ type ArticleRenderingHandlerFixture struct {
 	*suite.T
 	handler  *ArticleRenderingHandler
 	renderer *FakeRenderer
-	disk     *InMemoryFileSystem
+	disk     *FakeFileSystem
+	site     *SiteIndex
 	article  *contracts.Article
 }
 
 func (this *ArticleRenderingHandlerFixture) Setup() {
 	this.renderer = NewFakeRenderer()
-	this.disk = NewInMemoryFileSystem()
-	this.handler = NewArticleRenderingHandler(this.disk, this.renderer, "output/folder")
+	this.disk = NewFakeFileSystem()
+	this.site = NewSiteIndex()
+	this.site.Add(contracts.Article{
+		Metadata: contracts.ArticleMetadata{Slug: "/other/", Title: "Other", Date: Date(2020, 2, 9)},
+		Content:  contracts.ArticleContent{Converted: `<a href="/slug">see</a>`},
+	})
+	this.handler = NewArticleRenderingHandler(this.disk, this.renderer, this.site, false, 5, "output/folder")
 
 	this.article = &contracts.Article{
 		Metadata: contracts.ArticleMetadata{
@@ -39,6 +45,7 @@ func (this *ArticleRenderingHandlerFixture) Setup() {
 			Converted: "CONTENT",
 		},
 	}
+	this.site.Add(*this.article)
 }
 
 func (this *ArticleRenderingHandlerFixture) TestFileTemplateRenderedAndWrittenToDisk() {
@@ -48,10 +55,9 @@ func (this *ArticleRenderingHandlerFixture) TestFileTemplateRenderedAndWrittenTo
 
 	this.So(this.article.Error, should.BeNil)
 	this.assertArticleDataRendered()
-	this.So(this.disk.Files, should.Contain, "output/folder/slug")
-	if !this.So(this.disk.Files, should.Contain, "output/folder/slug/index.html") {
-		file := this.disk.Files["output/folder/slug/index.html"]
-		this.So(file.Content(), should.Equal, "RENDERED")
+	this.So(this.disk.Paths(), should.Contain, "output/folder/slug")
+	if !this.So(this.disk.Paths(), should.Contain, "output/folder/slug/index.html") {
+		this.So(this.disk.Content("output/folder/slug/index.html"), should.Equal, "RENDERED")
 	}
 }
 
@@ -63,18 +69,76 @@ func (this *ArticleRenderingHandlerFixture) assertArticleDataRendered() {
 		Date:    this.article.Metadata.Date,
 		Topics:  this.article.Metadata.Topics,
 		Content: this.article.Content.Converted,
+		Backlinks: []contracts.RenderedArticleSummary{
+			{Slug: "/other/", Title: "Other", Date: Date(2020, 2, 9)},
+		},
+		Next: &contracts.RenderedArticleSummary{Slug: "/other/", Title: "Other", Date: Date(2020, 2, 9)},
 	})
 }
 
+func (this *ArticleRenderingHandlerFixture) TestNeighborsScopedToSharedTopics() {
+	this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/older-a/", Date: Date(2020, 2, 1), Topics: []string{"a"}}})
+	this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/older-b/", Date: Date(2020, 2, 5), Topics: []string{"b"}}})
+	this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/older-c/", Date: Date(2020, 2, 7), Topics: []string{"c"}}})
+	this.handler = NewArticleRenderingHandler(this.disk, this.renderer, this.site, true, 5, "output/folder")
+
+	this.handler.Handle(this.article)
+
+	rendered := this.renderer.rendered.(contracts.RenderedArticle)
+	this.So(rendered.Previous.Slug, should.Equal, "/older-b/")
+	this.So(rendered.Next, should.BeNil)
+}
+
+func (this *ArticleRenderingHandlerFixture) TestSeriesListedWithCurrentPartMarked() {
+	this.article.Metadata.Series = "tutorial"
+	this.article.Metadata.SeriesPart = 2
+	this.site = NewSiteIndex()
+	this.site.Add(*this.article)
+	this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/first", Series: "tutorial", SeriesPart: 1}})
+	this.handler = NewArticleRenderingHandler(this.disk, this.renderer, this.site, false, 5, "output/folder")
+
+	this.handler.Handle(this.article)
+
+	series := this.renderer.rendered.(contracts.RenderedArticle).Series
+	this.So(series.Slug, should.Equal, "/series/tutorial/")
+	this.So(len(series.Parts), should.Equal, 2)
+	this.So(series.Parts[0].Slug, should.Equal, "/first")
+	this.So(series.Parts[0].Current, should.BeFalse)
+	this.So(series.Parts[1].Slug, should.Equal, "/slug")
+	this.So(series.Parts[1].Current, should.BeTrue)
+}
+
+func (this *ArticleRenderingHandlerFixture) TestPageRenderedAsPage() {
+	this.article.Metadata.Kind = contracts.KindPage
+	this.site = NewSiteIndex()
+	this.site.Add(*this.article)
+	this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/other/", Topics: []string{"a"}}})
+	this.handler = NewArticleRenderingHandler(this.disk, this.renderer, this.site, false, 5, "output/folder")
+
+	this.handler.Handle(this.article)
+
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.renderer.rendered, should.Equal, contracts.RenderedPage{RenderedArticle: contracts.RenderedArticle{
+		Slug:    this.article.Metadata.Slug,
+		Title:   this.article.Metadata.Title,
+		Intro:   this.article.Metadata.Intro,
+		Date:    this.article.Metadata.Date,
+		Topics:  this.article.Metadata.Topics,
+		Content: this.article.Content.Converted,
+	}})
+}
+
 func (this *ArticleRenderingHandlerFixture) TestRenderErrorReturned() {
 	renderErr := errors.New("boink")
 	this.renderer.err = renderErr
+	this.article.Source.Path = "content/a.md"
 
 	this.handler.Handle(this.article)
 
 	this.So(this.article.Error, should.WrapError, renderErr)
+	this.So(this.article.Error.Error(), should.Equal, "[content/a.md] boink")
 	this.assertArticleDataRendered()
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 
 func (this *ArticleRenderingHandlerFixture) TestMkdirAllErrorReturned() {
@@ -86,7 +150,7 @@ func (this *ArticleRenderingHandlerFixture) TestMkdirAllErrorReturned() {
 
 	this.So(this.article.Error, should.WrapError, mkdirErr)
 	this.assertArticleDataRendered()
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 
 func (this *ArticleRenderingHandlerFixture) TestWriteFileErrorReturned() {
@@ -98,7 +162,7 @@ func (this *ArticleRenderingHandlerFixture) TestWriteFileErrorReturned() {
 
 	this.So(this.article.Error, should.WrapError, writeFileErr)
 	this.assertArticleDataRendered()
-	this.So(this.disk.Files, should.NOT.Contain, "output/folder/slug/index.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "output/folder/slug/index.html")
 }
 
 ///////////////////////////////////////////////////////////////////
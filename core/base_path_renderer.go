@@ -20,6 +20,10 @@ func NewBasePathRenderer(inner contracts.Renderer, basePath string) *BasePathRen
 		basePath: fmt.Sprintf(`href="%s/$1`, basePath),
 	}
 }
+func (this *BasePathRenderer) HasTemplate(name string) bool {
+	templates, ok := this.inner.(contracts.TemplateSet)
+	return ok && templates.HasTemplate(name)
+}
 func (this *BasePathRenderer) Render(v any) (string, error) {
 	output, err := this.inner.Render(v)
 	return this.pattern.ReplaceAllString(output, this.basePath), err
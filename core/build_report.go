@@ -0,0 +1,129 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	OutcomePublished = "published"
+	OutcomeChecked   = "checked"
+	OutcomeDropped   = "dropped"
+	OutcomeError     = "error"
+)
+
+// BuildReport is a machine-readable account of a build, suitable for CI.
+type BuildReport struct {
+	Started      time.Time       `json:"started"`
+	Finished     time.Time       `json:"finished"`
+	Duration     time.Duration   `json:"duration_ns"`
+	Errors       int             `json:"errors"`
+	Warnings     int             `json:"warnings"`
+	Dropped      int             `json:"dropped"`
+	Published    int             `json:"published"`
+	Checked      int             `json:"checked,omitempty"`
+	Articles     []ArticleReport `json:"articles"`
+	SiteWarnings []string        `json:"site_warnings,omitempty"`
+}
+
+type ArticleReport struct {
+	Path     string   `json:"path"`
+	Slug     string   `json:"slug"`
+	Outcome  string   `json:"outcome"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (this BuildReport) JSON() ([]byte, error) {
+	if this.Articles == nil {
+		this.Articles = []ArticleReport{}
+	}
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(this)
+	return buffer.Bytes(), err
+}
+
+// JUnit renders the report as JUnit XML: each article is a test case,
+// errors are failures and dropped articles are skipped. Warnings are
+// included as each test case's (or, for the whole site, the suite's)
+// standard error.
+func (this BuildReport) JUnit() ([]byte, error) {
+	suite := junitTestSuite{
+		Name:      "hugoinho",
+		Tests:     len(this.Articles),
+		Failures:  this.Errors,
+		Skipped:   this.Dropped,
+		Time:      seconds(this.Duration),
+		Timestamp: this.Started.UTC().Format("2006-01-02T15:04:05"),
+		SystemErr: strings.Join(this.SiteWarnings, "\n"),
+	}
+	for _, article := range this.Articles {
+		testCase := junitTestCase{
+			ClassName: "content",
+			Name:      article.name(),
+			SystemErr: strings.Join(article.Warnings, "\n"),
+		}
+		switch article.Outcome {
+		case OutcomeError:
+			testCase.Failure = &junitMessage{Message: article.Error, Text: article.Error}
+		case OutcomeDropped:
+			testCase.Skipped = &junitMessage{Message: article.Error}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	output, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), output...), nil
+}
+
+func (this ArticleReport) name() string {
+	switch {
+	case this.Path != "":
+		return this.Path
+	case this.Slug != "":
+		return this.Slug
+	default:
+		return "(site)"
+	}
+}
+
+func seconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+type (
+	junitTestSuites struct {
+		XMLName xml.Name         `xml:"testsuites"`
+		Suites  []junitTestSuite `xml:"testsuite"`
+	}
+	junitTestSuite struct {
+		Name      string          `xml:"name,attr"`
+		Tests     int             `xml:"tests,attr"`
+		Failures  int             `xml:"failures,attr"`
+		Skipped   int             `xml:"skipped,attr"`
+		Time      string          `xml:"time,attr"`
+		Timestamp string          `xml:"timestamp,attr"`
+		TestCases []junitTestCase `xml:"testcase"`
+		SystemErr string          `xml:"system-err,omitempty"`
+	}
+	junitTestCase struct {
+		ClassName string        `xml:"classname,attr"`
+		Name      string        `xml:"name,attr"`
+		Failure   *junitMessage `xml:"failure,omitempty"`
+		Skipped   *junitMessage `xml:"skipped,omitempty"`
+		SystemErr string        `xml:"system-err,omitempty"`
+	}
+	junitMessage struct {
+		Message string `xml:"message,attr,omitempty"`
+		Text    string `xml:",chardata"`
+	}
+)
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestBuildReportFixture(t *testing.T) {
+	suite.Run(&BuildReportFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type BuildReportFixture struct {
+	*suite.T
+
+	report BuildReport
+}
+
+func (this *BuildReportFixture) Setup() {
+	started := Date(2022, 1, 1)
+	this.report = BuildReport{
+		Started:   started,
+		Finished:  started.Add(1500 * time.Millisecond),
+		Duration:  1500 * time.Millisecond,
+		Errors:    2,
+		Warnings:  2,
+		Dropped:   1,
+		Published: 1,
+		Articles: []ArticleReport{
+			{Path: "content/a.md", Slug: "/a/", Outcome: OutcomePublished, Warnings: []string{"missing metadata intro"}},
+			{Path: "content/b.md", Slug: "/b/", Outcome: OutcomeDropped, Error: "dropped article: /b/ (DRAFT)"},
+			{Path: "content/c.md", Outcome: OutcomeError, Error: "[content/c.md] blank metadata slug"},
+			{Outcome: OutcomeError, Error: "<failed> & more"},
+		},
+		SiteWarnings: []string{"lonely topic"},
+	}
+}
+
+func (this *BuildReportFixture) TestJSON() {
+	output, err := this.report.JSON()
+
+	this.So(err, should.BeNil)
+	this.So(string(output), should.Equal, `{
+  "started": "2022-01-01T00:00:00Z",
+  "finished": "2022-01-01T00:00:01.5Z",
+  "duration_ns": 1500000000,
+  "errors": 2,
+  "warnings": 2,
+  "dropped": 1,
+  "published": 1,
+  "articles": [
+    {
+      "path": "content/a.md",
+      "slug": "/a/",
+      "outcome": "published",
+      "warnings": [
+        "missing metadata intro"
+      ]
+    },
+    {
+      "path": "content/b.md",
+      "slug": "/b/",
+      "outcome": "dropped",
+      "error": "dropped article: /b/ (DRAFT)"
+    },
+    {
+      "path": "content/c.md",
+      "slug": "",
+      "outcome": "error",
+      "error": "[content/c.md] blank metadata slug"
+    },
+    {
+      "path": "",
+      "slug": "",
+      "outcome": "error",
+      "error": "<failed> & more"
+    }
+  ],
+  "site_warnings": [
+    "lonely topic"
+  ]
+}
+`)
+}
+
+func (this *BuildReportFixture) TestJSONWithoutArticles() {
+	output, err := BuildReport{}.JSON()
+
+	this.So(err, should.BeNil)
+	this.So(string(output), should.Contain, `"articles": []`)
+}
+
+func (this *BuildReportFixture) TestJUnit() {
+	output, err := this.report.JUnit()
+
+	this.So(err, should.BeNil)
+	this.So(string(output), should.Equal, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="hugoinho" tests="4" failures="2" skipped="1" time="1.500" timestamp="2022-01-01T00:00:00">
+    <testcase classname="content" name="content/a.md">
+      <system-err>missing metadata intro</system-err>
+    </testcase>
+    <testcase classname="content" name="content/b.md">
+      <skipped message="dropped article: /b/ (DRAFT)"></skipped>
+    </testcase>
+    <testcase classname="content" name="content/c.md">
+      <failure message="[content/c.md] blank metadata slug">[content/c.md] blank metadata slug</failure>
+    </testcase>
+    <testcase classname="content" name="(site)">
+      <failure message="&lt;failed&gt; &amp; more">&lt;failed&gt; &amp; more</failure>
+    </testcase>
+    <system-err>lonely topic</system-err>
+  </testsuite>
+</testsuites>`)
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// Exit codes returned by CheckRunner.Run.
+const (
+	CheckPassed  = 0
+	CheckFailed  = 1
+	CheckInvalid = 2
+)
+
+// CheckRunner validates content (reading, parsing, filtering and
+// converting every article) without loading templates or writing a site.
+type CheckRunner struct {
+	version string
+	args    []string
+	fs      contracts.FileSystem
+	now     contracts.Clock
+	output  io.Writer
+	log     contracts.Logger
+}
+
+func NewCheckRunner(
+	version string,
+	args []string,
+	fs contracts.FileSystem,
+	now contracts.Clock,
+	output io.Writer,
+) *CheckRunner {
+	return &CheckRunner{
+		version: version,
+		args:    args,
+		fs:      fs,
+		now:     now,
+		output:  output,
+		log:     NewLogger(output, LogFormatText, slog.LevelInfo),
+	}
+}
+
+func (this *CheckRunner) Run() int {
+	start := this.now()
+
+	config, err := NewCLIParser(this.version, this.args).ParseCheck()
+	if err != nil {
+		this.log.Error("invalid configuration", "error", err)
+		return CheckInvalid
+	}
+	this.log = NewLogger(this.output, config.LogFormat, config.LogLevel)
+
+	reporter := NewCheckReporter(start, this.log)
+	pipeline := NewPipeline(this.now, config, this.fs, nil, reporter)
+	reporter.ProcessStream(pipeline.Check())
+	reporter.ProcessWarnings(pipeline.Warnings())
+
+	if finish(this.log, this.fs, config, reporter, this.now(), nil) > 0 {
+		return CheckFailed
+	}
+	return CheckPassed
+}
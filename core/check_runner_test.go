@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestCheckRunnerFixture(t *testing.T) {
+	suite.Run(&CheckRunnerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type CheckRunnerFixture struct {
+	*suite.T
+
+	log  *bytes.Buffer
+	args []string
+	disk *FakeFileSystem
+}
+
+func (this *CheckRunnerFixture) Setup() {
+	this.log = new(bytes.Buffer)
+	this.disk = NewFakeFileSystem()
+
+	this.file("content/a.md", ContentA)
+	this.file("content/b.md", ContentB)
+	this.file("content/c.md", ContentC)
+}
+
+func (this *CheckRunnerFixture) file(path, content string) {
+	this.disk.Write(path, content)
+}
+func (this *CheckRunnerFixture) run() int {
+	now := func() time.Time { return Date(2022, 1, 1) }
+	return NewCheckRunner("version", this.args, this.disk, now, this.log).Run()
+}
+
+func (this *CheckRunnerFixture) TestValidContentPasses_NothingWritten() {
+	files := len(this.disk.Files())
+
+	this.So(this.run(), should.Equal, CheckPassed)
+	this.So(len(this.disk.Files()), should.Equal, files)
+	this.So(this.log.String(), should.Contain, `msg="build finished" errors=0 warnings=0 dropped=1 checked=2`)
+	this.So(this.log.String(), should.Contain, `msg="checked article" path=content/a.md`)
+	this.So(this.log.String(), should.NOT.Contain, "published")
+}
+
+func (this *CheckRunnerFixture) TestInvalidContentFails() {
+	this.file("content/d.md", "not an article")
+
+	this.So(this.run(), should.Equal, CheckFailed)
+	this.So(this.log.String(), should.Contain, `msg="article failed" path=content/d.md`)
+}
+
+func (this *CheckRunnerFixture) TestWarningsFailOnlyWhenRequested() {
+	this.file("content/d.md", "title: D\nslug: /d/\ndate: 2021-01-01\n\n+++\n\nD")
+
+	this.So(this.run(), should.Equal, CheckPassed)
+
+	this.args = []string{"-fail-on-warnings"}
+	this.So(this.run(), should.Equal, CheckFailed)
+}
+
+func (this *CheckRunnerFixture) TestInvalidFlags() {
+	this.args = []string{"-target", "rendered"}
+
+	this.So(this.run(), should.Equal, CheckInvalid)
+}
+
+func (this *CheckRunnerFixture) TestReportWritten() {
+	this.args = []string{"-report", "report.json"}
+
+	this.So(this.run(), should.Equal, CheckPassed)
+	var report BuildReport
+	this.So(json.Unmarshal([]byte(this.disk.Content("report.json")), &report), should.BeNil)
+	this.So(report.Checked, should.Equal, 2)
+	this.So(report.Published, should.Equal, 0)
+	this.So(report.Articles[0].Outcome, should.Equal, OutcomeChecked)
+}
+
+func (this *CheckRunnerFixture) TestReportWriteFailureFails() {
+	this.args = []string{"-report", "report.json"}
+	this.disk.ErrWriteFile["report.json"] = errors.New("boink")
+
+	this.So(this.run(), should.Equal, CheckFailed)
+}
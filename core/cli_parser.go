@@ -5,6 +5,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"log/slog"
 	"path/filepath"
 	"strings"
 
@@ -30,24 +31,57 @@ func NewCLIParser(version string, args []string) *CLIParser {
 }
 
 func (this *CLIParser) Parse() (config contracts.Config, err error) {
-	this.stringFlag("templates", "Directory with html templates.     ", "templates", &config.TemplateDir)
-	this.stringFlag("content  ", "Directory with markdown content.   ", "content  ", &config.ContentRoot)
-	this.stringFlag("target   ", "Directory for rendered html.       ", "rendered ", &config.TargetRoot)
-	this.stringFlag("base-path", "Initial path of rendered html.     ", "         ", &config.BasePath)
-	this.boolFlag("with-drafts", "When set, include drafts.             ", false, &config.BuildDrafts)
-	this.boolFlag("with-future", "When set, include future articles.    ", false, &config.BuildFuture)
-
-	err = this.flags.Parse(this.args)
+	this.contentFlags(&config)
+	this.stringFlag("templates    ", "Directory with html templates.              ", "templates   ", &config.TemplateDir)
+	this.stringFlag("target       ", "Directory for rendered html.                ", "rendered    ", &config.TargetRoot)
+	this.stringFlag("base-path    ", "Initial path of rendered html.              ", "            ", &config.BasePath)
+	this.stringFlag("trace        ", "File for a Chrome trace of the run.         ", "            ", &config.TracePath)
+	this.stringFlag("topic-order  ", "Order of topics: alphabetical or popularity.", "alphabetical", &config.TopicOrder)
+	this.boolFlag("in-place        ", "When set, write directly to target.   ", false, &config.BuildInPlace)
+	this.boolFlag("prune           ", "When set, delete stale target files.  ", false, &config.Prune)
+	this.boolFlag("prune-dry-run   ", "When set, list stale target files.    ", false, &config.PruneDryRun)
+	this.boolFlag("dry-run         ", "When set, report (but skip) writes.   ", false, &config.DryRun)
+	this.boolFlag("topic-neighbors ", "When set, link previous/next by topic.", false, &config.TopicNeighbors)
+	this.intFlag("related    ", "Number of related articles to list.        ", 5, &config.RelatedCount)
+	this.intFlag("home-recent", "Number of recent articles on the homepage. ", 10, &config.HomeRecent)
+	this.intFlag("home-topics", "Number of topics on the homepage.          ", 30, &config.HomeTopics)
+	this.intFlag("topic-min  ", "Minimum number of articles to list a topic.", 2, &config.TopicMinimum)
+	this.listFlag("allow-links", "Url path prefixes (comma-separated) of files placed in the target by hand.", &config.AllowedLinks)
+	return this.parse(&config, validateConfig)
+}
+
+// ParseCheck accepts only the flags that affect which content is read
+// and how problems are reported (there are no templates or target).
+func (this *CLIParser) ParseCheck() (config contracts.Config, err error) {
+	this.contentFlags(&config)
+	return this.parse(&config, validateCheckConfig)
+}
+
+func (this *CLIParser) contentFlags(config *contracts.Config) {
+	this.stringFlag("content      ", "Directory with markdown content.            ", "content     ", &config.ContentRoot)
+	this.stringFlag("report       ", "File for a machine-readable report.         ", "            ", &config.ReportPath)
+	this.stringFlag("report-format", "Format of -report: json or junit.           ", "json        ", &config.ReportFormat)
+	this.stringFlag("log-format   ", "Format of log output: text or json.         ", "text        ", &config.LogFormat)
+	this.levelFlag("log-level", "Minimum level: debug, info, warn or error.", slog.LevelInfo, &config.LogLevel)
+	this.boolFlag("with-drafts     ", "When set, include drafts.             ", false, &config.BuildDrafts)
+	this.boolFlag("with-future     ", "When set, include future articles.    ", false, &config.BuildFuture)
+	this.boolFlag("fail-on-warnings", "When set, treat warnings as errors.   ", false, &config.FailOnWarnings)
+}
+
+// parse fills in config (to which the flags are bound) and validates
+// the result.
+func (this *CLIParser) parse(config *contracts.Config, validate func(contracts.Config) error) (contracts.Config, error) {
+	err := this.flags.Parse(this.args)
 	if err != nil {
 		return contracts.Config{}, this.composeError(err)
 	}
 
-	err = validateConfig(config)
+	err = validate(*config)
 	if err != nil {
 		return contracts.Config{}, this.composeError(err)
 	}
 
-	return config, nil
+	return *config, nil
 }
 
 func (this *CLIParser) composeError(err error) error {
@@ -70,24 +104,107 @@ func (this *CLIParser) boolFlag(name, description string, value bool, b *bool) {
 	)
 }
 
+func (this *CLIParser) intFlag(name, description string, value int, i *int) {
+	this.flags.IntVar(i,
+		strings.TrimSpace(name),
+		value,
+		strings.TrimSpace(description),
+	)
+}
+
+func (this *CLIParser) listFlag(name, description string, list *[]string) {
+	this.flags.Func(
+		strings.TrimSpace(name),
+		strings.TrimSpace(description),
+		func(value string) error {
+			for item := range strings.SplitSeq(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*list = append(*list, item)
+				}
+			}
+			return nil
+		},
+	)
+}
+
+func (this *CLIParser) levelFlag(name, description string, value slog.Level, level *slog.Level) {
+	this.flags.TextVar(level,
+		strings.TrimSpace(name),
+		value,
+		strings.TrimSpace(description),
+	)
+}
+
 func validateConfig(config contracts.Config) error {
 	if config.TemplateDir == "" {
 		return errors.New("template directory is required")
 	}
-	if config.ContentRoot == "" {
-		return errors.New("content directory is required")
-	}
 	if config.TargetRoot == "" {
 		return errors.New("target directory is required")
 	}
+	if config.RelatedCount < 0 {
+		return fmt.Errorf("related article count must not be negative: %d", config.RelatedCount)
+	}
+	if config.HomeRecent < 0 {
+		return fmt.Errorf("homepage recent article count must not be negative: %d", config.HomeRecent)
+	}
+	if config.HomeTopics < 0 {
+		return fmt.Errorf("homepage topic count must not be negative: %d", config.HomeTopics)
+	}
+	if config.TopicMinimum < 1 {
+		return fmt.Errorf("topic minimum article count must be at least 1: %d", config.TopicMinimum)
+	}
+	if config.TopicOrder != TopicOrderAlphabetical && config.TopicOrder != TopicOrderPopularity {
+		return errors.New("topic order must be alphabetical or popularity: " + config.TopicOrder)
+	}
+	for _, prefix := range config.AllowedLinks {
+		if !strings.HasPrefix(prefix, "/") {
+			return errors.New("allowed link prefix must start with a slash: " + prefix)
+		}
+	}
+	if err := validateCheckConfig(config); err != nil {
+		return err
+	}
 	if hasPathTraversal(config.TemplateDir) {
 		return errors.New("template directory contains path traversal: " + sanitizeForError(config.TemplateDir))
 	}
+	if hasPathTraversal(config.TargetRoot) {
+		return errors.New("target directory contains path traversal: " + sanitizeForError(config.TargetRoot))
+	}
+	if hasPathTraversal(config.TracePath) {
+		return errors.New("trace file contains path traversal: " + sanitizeForError(config.TracePath))
+	}
+	if config.Prune && !config.BuildInPlace {
+		return errors.New("-prune requires -in-place (a staged build already leaves stale files behind in the previous target)")
+	}
+	if !config.BuildInPlace && !config.DryRun && !isRenamable(config.TargetRoot) {
+		return errors.New("target directory can't be swapped by a staged build (use -in-place): " + config.TargetRoot)
+	}
+	return nil
+}
+
+// isRenamable checks whether a staged build (see Publisher) can rename the
+// target directory, which rules out the current and root directories.
+func isRenamable(path string) bool {
+	base := filepath.Base(filepath.Clean(path))
+	return base != "." && base != string(filepath.Separator)
+}
+
+func validateCheckConfig(config contracts.Config) error {
+	if config.ContentRoot == "" {
+		return errors.New("content directory is required")
+	}
+	if config.ReportFormat != ReportFormatJSON && config.ReportFormat != ReportFormatJUnit {
+		return errors.New("report format must be json or junit: " + config.ReportFormat)
+	}
+	if config.LogFormat != LogFormatText && config.LogFormat != LogFormatJSON {
+		return errors.New("log format must be text or json: " + config.LogFormat)
+	}
 	if hasPathTraversal(config.ContentRoot) {
 		return errors.New("content directory contains path traversal: " + sanitizeForError(config.ContentRoot))
 	}
-	if hasPathTraversal(config.TargetRoot) {
-		return errors.New("target directory contains path traversal: " + sanitizeForError(config.TargetRoot))
+	if hasPathTraversal(config.ReportPath) {
+		return errors.New("report file contains path traversal: " + sanitizeForError(config.ReportPath))
 	}
 	return nil
 }
@@ -111,4 +228,39 @@ func sanitizeForError(path string) string {
 	return strings.ReplaceAll(path, "..", "<traversal>")
 }
 
+// ExtractFlag removes the named flag (and its value) from args so that a
+// binary can accept a flag of its own before handing the remaining args to
+// CLIParser, which rejects flags it doesn't know. Both "-name value" and
+// "-name=value" (with one or two dashes) are recognized; the last occurrence
+// wins. Nothing after a "--" is considered.
+func ExtractFlag(args []string, name, fallback string) (value string, rest []string, err error) {
+	value = fallback
+	for x := 0; x < len(args); x++ {
+		arg := args[x]
+		if arg == "--" {
+			return value, append(rest, args[x:]...), nil
+		}
+		trimmed := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		switch {
+		case arg == trimmed:
+			rest = append(rest, arg)
+		case trimmed == name && x+1 < len(args):
+			value = args[x+1]
+			x++
+		case trimmed == name:
+			return "", nil, fmt.Errorf("%w: flag needs an argument: -%s", ErrInvalidConfig, name)
+		case strings.HasPrefix(trimmed, name+"="):
+			value = strings.TrimPrefix(trimmed, name+"=")
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return value, rest, nil
+}
+
 var ErrInvalidConfig = errors.New("invalid config")
+
+const (
+	ReportFormatJSON  = "json"
+	ReportFormatJUnit = "junit"
+)
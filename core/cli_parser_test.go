@@ -2,6 +2,7 @@ package core
 
 import (
 	"bytes"
+	"log/slog"
 	"testing"
 
 	"github.com/mdw-go/testing/v2/should"
@@ -35,12 +36,20 @@ func (this *CLIParserFixture) TestDefaults() {
 	config, err := this.Parse()
 	this.So(err, should.BeNil)
 	this.So(config, should.Equal, contracts.Config{
-		TemplateDir: "templates",
-		ContentRoot: "content",
-		TargetRoot:  "rendered",
-		BasePath:    "",
-		BuildDrafts: false,
-		BuildFuture: false,
+		TemplateDir:  "templates",
+		ContentRoot:  "content",
+		TargetRoot:   "rendered",
+		BasePath:     "",
+		BuildDrafts:  false,
+		BuildFuture:  false,
+		RelatedCount: 5,
+		HomeRecent:   10,
+		HomeTopics:   30,
+		TopicMinimum: 2,
+		TopicOrder:   "alphabetical",
+		ReportFormat: "json",
+		LogFormat:    "text",
+		LogLevel:     slog.LevelInfo,
 	})
 }
 
@@ -52,16 +61,49 @@ func (this *CLIParserFixture) TestCustomValues() {
 		"-base-path", "/path",
 		"-with-drafts",
 		"-with-future",
+		"-in-place",
+		"-prune",
+		"-prune-dry-run",
+		"-dry-run",
+		"-topic-neighbors",
+		"-related", "3",
+		"-home-recent", "4",
+		"-home-topics", "0",
+		"-topic-min", "1",
+		"-topic-order", "popularity",
+		"-allow-links", "/img/,/favicon.ico",
+		"-fail-on-warnings",
+		"-report", "report.xml",
+		"-report-format", "junit",
+		"-trace", "trace.json",
+		"-log-format", "json",
+		"-log-level", "debug",
 	}
 	config, err := this.Parse()
 	this.So(err, should.BeNil)
 	this.So(config, should.Equal, contracts.Config{
-		TemplateDir: "other-templates",
-		ContentRoot: "other-content",
-		TargetRoot:  "other-rendered",
-		BasePath:    "/path",
-		BuildDrafts: true,
-		BuildFuture: true,
+		TemplateDir:    "other-templates",
+		ContentRoot:    "other-content",
+		TargetRoot:     "other-rendered",
+		BasePath:       "/path",
+		BuildDrafts:    true,
+		BuildFuture:    true,
+		BuildInPlace:   true,
+		Prune:          true,
+		PruneDryRun:    true,
+		DryRun:         true,
+		FailOnWarnings: true,
+		TopicNeighbors: true,
+		RelatedCount:   3,
+		HomeRecent:     4,
+		TopicMinimum:   1,
+		TopicOrder:     "popularity",
+		AllowedLinks:   []string{"/img/", "/favicon.ico"},
+		ReportPath:     "report.xml",
+		ReportFormat:   "junit",
+		TracePath:      "trace.json",
+		LogFormat:      "json",
+		LogLevel:       slog.LevelDebug,
 	})
 }
 
@@ -86,6 +128,73 @@ func (this *CLIParserFixture) TestMissingTargetFolder() {
 	this.So(config, should.Equal, contracts.Config{})
 }
 
+func (this *CLIParserFixture) TestUnknownReportFormat() {
+	this.args = []string{"-report-format", "yaml"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestNegativeRelatedCount() {
+	this.args = []string{"-related", "-1"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestNegativeHomepageCounts() {
+	for _, flag := range []string{"-home-recent", "-home-topics"} {
+		this.args = []string{flag, "-1"}
+		config, err := this.Parse()
+		this.So(err, should.WrapError, ErrInvalidConfig)
+		this.So(config, should.Equal, contracts.Config{})
+	}
+}
+
+func (this *CLIParserFixture) TestAllowedLinkPrefixWithoutSlash() {
+	this.args = []string{"-allow-links", "/img/,favicon.ico"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestUnrenamableTargetForStagedBuild() {
+	for _, target := range []string{".", "./", "/"} {
+		this.args = []string{"-target", target}
+		config, err := this.Parse()
+		this.So(err, should.WrapError, ErrInvalidConfig)
+		this.So(config, should.Equal, contracts.Config{})
+	}
+}
+
+func (this *CLIParserFixture) TestUnrenamableTargetForInPlaceBuild() {
+	this.args = []string{"-target", ".", "-in-place"}
+	config, err := this.Parse()
+	this.So(err, should.BeNil)
+	this.So(config.TargetRoot, should.Equal, ".")
+}
+
+func (this *CLIParserFixture) TestPruneWithoutInPlace() {
+	this.args = []string{"-prune"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestTopicMinimumBelowOne() {
+	this.args = []string{"-topic-min", "0"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestBogusTopicOrder() {
+	this.args = []string{"-topic-order", "random"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
 func (this *CLIParserFixture) TestBogusValue() {
 	this.args = []string{"-bogus"}
 	config, err := this.Parse()
@@ -117,6 +226,22 @@ func (this *CLIParserFixture) TestPathTraversalInTemplates() {
 	this.So(err.Error(), should.Contain, "path traversal")
 }
 
+func (this *CLIParserFixture) TestPathTraversalInReport() {
+	this.args = []string{"-report", "../report.json"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+	this.So(err.Error(), should.Contain, "path traversal")
+}
+
+func (this *CLIParserFixture) TestPathTraversalInTrace() {
+	this.args = []string{"-trace", "../trace.json"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+	this.So(err.Error(), should.Contain, "path traversal")
+}
+
 func (this *CLIParserFixture) TestValidNestedPath() {
 	this.args = []string{"-content", "content/posts", "-target", "rendered/html"}
 	config, err := this.Parse()
@@ -149,3 +274,112 @@ func (this *CLIParserFixture) TestSanitizeForError() {
 	this.So(sanitizeForError("../../etc"), should.Equal, "<traversal>/<traversal>/etc")
 	this.So(sanitizeForError("normal/path"), should.Equal, "normal/path")
 }
+
+func (this *CLIParserFixture) TestUnknownLogFormat() {
+	this.args = []string{"-log-format", "xml"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestUnknownLogLevel() {
+	this.args = []string{"-log-level", "chatty"}
+	config, err := this.Parse()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) ParseCheck() (contracts.Config, error) {
+	parser := NewCLIParser("version", this.args)
+	parser.flags.SetOutput(this.output)
+	return parser.ParseCheck()
+}
+
+func (this *CLIParserFixture) TestCheckDefaults() {
+	this.args = []string{}
+	config, err := this.ParseCheck()
+	this.So(err, should.BeNil)
+	this.So(config, should.Equal, contracts.Config{
+		ContentRoot:  "content",
+		ReportFormat: "json",
+		LogFormat:    "text",
+		LogLevel:     slog.LevelInfo,
+	})
+}
+
+func (this *CLIParserFixture) TestCheckCustomValues() {
+	this.args = []string{
+		"-content", "other-content",
+		"-with-drafts",
+		"-with-future",
+		"-fail-on-warnings",
+		"-report", "report.xml",
+		"-report-format", "junit",
+		"-log-format", "json",
+		"-log-level", "warn",
+	}
+	config, err := this.ParseCheck()
+	this.So(err, should.BeNil)
+	this.So(config, should.Equal, contracts.Config{
+		ContentRoot:    "other-content",
+		BuildDrafts:    true,
+		BuildFuture:    true,
+		FailOnWarnings: true,
+		ReportPath:     "report.xml",
+		ReportFormat:   "junit",
+		LogFormat:      "json",
+		LogLevel:       slog.LevelWarn,
+	})
+}
+
+func (this *CLIParserFixture) TestCheckRejectsRenderingFlags() {
+	this.args = []string{"-templates", "templates"}
+	config, err := this.ParseCheck()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+}
+
+func (this *CLIParserFixture) TestCheckPathTraversalInContent() {
+	this.args = []string{"-content", "../../secret"}
+	config, err := this.ParseCheck()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+	this.So(err.Error(), should.Contain, "path traversal")
+}
+
+func (this *CLIParserFixture) TestCheckPathTraversalInReport() {
+	this.args = []string{"-report", "../report.json"}
+	config, err := this.ParseCheck()
+	this.So(err, should.WrapError, ErrInvalidConfig)
+	this.So(config, should.Equal, contracts.Config{})
+	this.So(err.Error(), should.Contain, "path traversal")
+}
+
+func (this *CLIParserFixture) TestExtractFlag() {
+	for _, test := range []struct {
+		args  []string
+		value string
+		rest  []string
+		err   bool
+	}{
+		{args: nil, value: "fallback"},
+		{args: []string{"-content", "c"}, value: "fallback", rest: []string{"-content", "c"}},
+		{args: []string{"-listen", ":1", "-content", "c"}, value: ":1", rest: []string{"-content", "c"}},
+		{args: []string{"-content", "c", "--listen", ":1"}, value: ":1", rest: []string{"-content", "c"}},
+		{args: []string{"-listen=:1", "-with-drafts"}, value: ":1", rest: []string{"-with-drafts"}},
+		{args: []string{"--listen=", "-with-drafts"}, value: "", rest: []string{"-with-drafts"}},
+		{args: []string{"-listen", ":1", "-listen=:2"}, value: ":2"},
+		{args: []string{"-listener", ":1"}, value: "fallback", rest: []string{"-listener", ":1"}},
+		{args: []string{"-with-drafts", "--", "-listen", ":1"}, value: "fallback", rest: []string{"-with-drafts", "--", "-listen", ":1"}},
+		{args: []string{"-content", "c", "-listen"}, err: true},
+	} {
+		value, rest, err := ExtractFlag(test.args, "listen", "fallback")
+		if test.err {
+			this.So(err, should.WrapError, ErrInvalidConfig)
+			continue
+		}
+		this.So(err, should.BeNil)
+		this.So(value, should.Equal, test.value)
+		this.So(rest, should.Equal, test.rest)
+	}
+}
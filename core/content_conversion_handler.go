@@ -26,4 +26,7 @@ func (this *ContentConversionHandler) Handle(article *contracts.Article) {
 
 	article.Content.Original = original
 	article.Content.Converted = converted
+	if original == "" {
+		article.Warn(errEmptyContent)
+	}
 }
@@ -52,6 +52,15 @@ func (this *ContentConversionHandlerFixture) TestInvalidContentElicitsError() {
 	this.So(article.Content, should.Equal, contracts.ArticleContent{})
 }
 
+func (this *ContentConversionHandlerFixture) TestEmptyContentElicitsWarning() {
+	article := &contracts.Article{Source: contracts.ArticleSource{Data: this.formatSourceData(" \n ")}}
+
+	this.converter.Handle(article)
+
+	this.So(article.Error, should.BeNil)
+	this.So(article.Warnings, should.Equal, []error{errEmptyContent})
+}
+
 ////////////////////////////////////////////////////
 
 type FakeConverter struct {
@@ -16,14 +16,14 @@ func TestFileReaderFixture(t *testing.T) {
 type FileReaderFixture struct {
 	*suite.T
 	reader *FileReadingHandler
-	files  *InMemoryFileSystem
+	files  *FakeFileSystem
 }
 
 func (this *FileReaderFixture) Setup() {
-	this.files = NewInMemoryFileSystem()
+	this.files = NewFakeFileSystem()
 	this.reader = NewFileReadingHandler(this.files)
 
-	_ = this.files.WriteFile("/file1", []byte("FILE1"), 0644)
+	this.files.Write("/file1", "FILE1")
 }
 
 func (this *FileReaderFixture) TestRead() {
@@ -9,55 +9,51 @@ import (
 	"github.com/mdw-tools/hugoinho/contracts"
 )
 
+// HomepageRenderingHandler lists the pinned articles (lowest weight first)
+// above the most recent of the others, along with the most used topics,
+// from the site index once every article has streamed past.
 type HomepageRenderingHandler struct {
-	pages    []contracts.RenderedArticleSummary
-	topics   leaderboard[string]
-	filter   contracts.Filter
-	sorter   contracts.Sorter
+	site     *SiteIndex
+	counts   HomepageCounts
+	settings TopicSettings
 	renderer contracts.Renderer
 	disk     RenderingFileSystem
 	output   string
 }
 
 func NewHomepageRenderingHandler(
-	filter contracts.Filter,
-	sorter contracts.Sorter,
+	site *SiteIndex,
 	renderer contracts.Renderer,
 	disk RenderingFileSystem,
+	counts HomepageCounts,
+	settings TopicSettings,
 	output string,
 ) *HomepageRenderingHandler {
 	return &HomepageRenderingHandler{
-		filter:   filter,
-		sorter:   sorter,
+		site:     site,
+		counts:   counts,
+		settings: settings,
 		renderer: renderer,
 		disk:     disk,
 		output:   output,
-		topics:   make(leaderboard[string]),
 	}
 }
-func (this *HomepageRenderingHandler) Handle(article *contracts.Article) {
-	if !this.filter(article) {
-		return
-	}
-	for topic := range slices.Values(article.Metadata.Topics) {
-		this.topics[topic]++
-	}
-	this.pages = append(this.pages, contracts.RenderedArticleSummary{
-		Slug:   article.Metadata.Slug,
-		Title:  article.Metadata.Title,
-		Intro:  article.Metadata.Intro,
-		Date:   article.Metadata.Date,
-		Topics: article.Metadata.Topics,
-		Draft:  article.Metadata.Draft,
-	})
-}
+func (this *HomepageRenderingHandler) Handle(*contracts.Article) {}
 func (this *HomepageRenderingHandler) Finalize() error {
-	if len(this.pages) == 0 {
+	articles := this.site.Articles()
+	if len(articles) == 0 {
 		return nil
 	}
+	pinned := this.site.Pinned()
+	recent := slices.DeleteFunc(slices.Clone(articles), func(article contracts.RenderedArticleSummary) bool {
+		return slices.ContainsFunc(pinned, func(other contracts.RenderedArticleSummary) bool {
+			return other.Slug == article.Slug
+		})
+	})
 	rendered, err := this.renderer.Render(contracts.RenderedHomePage{
-		ProminentTopics: slices.SortedStableFunc(slices.Values(this.topics.TopN(30)), cmp.Compare),
-		Pages:           slices.SortedStableFunc(slices.Values(this.pages), this.sorter)[:min(len(this.pages), 10)],
+		ProminentTopics: this.prominentTopics(countTopics(articles)),
+		Pinned:          pinned,
+		Recent:          recent[:min(len(recent), this.counts.Recent)],
 	})
 	if err != nil {
 		return err
@@ -76,8 +72,37 @@ func (this *HomepageRenderingHandler) Finalize() error {
 	return nil
 }
 
+// prominentTopics are the most used of the topics listed on the topics
+// page, in the configured order.
+func (this *HomepageRenderingHandler) prominentTopics(counts leaderboard[string]) (topics []contracts.RenderedTopic) {
+	for _, topic := range this.settings.sort(counts, counts.TopN(this.counts.Topics)) {
+		if counts[topic] >= this.settings.Minimum {
+			topics = append(topics, contracts.RenderedTopic{Topic: topic, Count: counts[topic]})
+		}
+	}
+	return topics
+}
+
+// HomepageCounts limit how many of the (unpinned) most recent articles and
+// of the most used topics the homepage lists.
+type HomepageCounts struct {
+	Recent int
+	Topics int
+}
+
 type leaderboard[T cmp.Ordered] map[T]int
 
+// countTopics counts how many of the articles mention each topic.
+func countTopics(articles []contracts.RenderedArticleSummary) leaderboard[string] {
+	counts := make(leaderboard[string])
+	for _, article := range articles {
+		for _, topic := range slices.Compact(slices.Sorted(slices.Values(article.Topics))) {
+			counts[topic]++
+		}
+	}
+	return counts
+}
+
 func (this leaderboard[T]) compare(i, j T) int {
 	rank := -cmp.Compare(this[i], this[j])
 	if rank == 0 {
@@ -2,7 +2,6 @@ package core
 
 import (
 	"errors"
-	"strings"
 	"testing"
 
 	"github.com/mdw-go/testing/v2/better"
@@ -19,8 +18,9 @@ type HomepageRenderingHandlerSuite struct {
 	*suite.T
 
 	handler  *HomepageRenderingHandler
+	site     *SiteIndex
 	renderer *FakeRenderer
-	disk     *InMemoryFileSystem
+	disk     *FakeFileSystem
 }
 
 var (
@@ -64,24 +64,30 @@ var (
 			Date:   Date(2023, 7, 9),
 		},
 	}
+	standalonePage = &contracts.Article{
+		Metadata: contracts.ArticleMetadata{
+			Slug:   "/page",
+			Title:  "Page",
+			Topics: []string{"topic-a"},
+			Kind:   contracts.KindPage,
+		},
+	}
 )
 
-func (this *HomepageRenderingHandlerSuite) filter(article *contracts.Article) bool {
-	return article.Metadata.Title < "C"
-}
-func (this *HomepageRenderingHandlerSuite) sorter(i, j contracts.RenderedArticleSummary) int {
-	return strings.Compare(i.Title, j.Title)
-}
 func (this *HomepageRenderingHandlerSuite) assertHandledArticlesRendered() {
 	this.So(this.renderer.rendered, should.Equal, contracts.RenderedHomePage{
-		ProminentTopics: []string{"topic-a", "topic-b"},
-		Pages: []contracts.RenderedArticleSummary{
+		ProminentTopics: []contracts.RenderedTopic{
+			{Topic: "topic-a", Count: 1},
+			{Topic: "topic-b", Count: 2},
+			{Topic: "topic-c", Count: 1},
+		},
+		Recent: []contracts.RenderedArticleSummary{
 			{
-				Slug:   "/a",
-				Title:  "A",
-				Intro:  "aa",
-				Date:   Date(2023, 7, 7),
-				Topics: []string{"topic-a"},
+				Slug:   "/c",
+				Title:  "C",
+				Intro:  "cc",
+				Date:   Date(2023, 7, 9),
+				Topics: []string{"topic-c"},
 				Draft:  false,
 			},
 			{
@@ -100,26 +106,41 @@ func (this *HomepageRenderingHandlerSuite) assertHandledArticlesRendered() {
 				Topics: []string{"topic-b"},
 				Draft:  true,
 			},
+			{
+				Slug:   "/a",
+				Title:  "A",
+				Intro:  "aa",
+				Date:   Date(2023, 7, 7),
+				Topics: []string{"topic-a"},
+				Draft:  false,
+			},
 		},
 	})
 }
 func (this *HomepageRenderingHandlerSuite) Setup() {
 	this.renderer = NewFakeRenderer()
-	this.disk = NewInMemoryFileSystem()
-	this.handler = NewHomepageRenderingHandler(this.filter, this.sorter, this.renderer, this.disk, "output/folder")
+	this.disk = NewFakeFileSystem()
+	this.site = NewSiteIndex()
+	this.handler = this.newHandler(HomepageCounts{Recent: 10, Topics: 30}, TopicSettings{Minimum: 1, Order: TopicOrderAlphabetical})
+}
+func (this *HomepageRenderingHandlerSuite) newHandler(counts HomepageCounts, settings TopicSettings) *HomepageRenderingHandler {
+	return NewHomepageRenderingHandler(this.site, this.renderer, this.disk, counts, settings, "output/folder")
+}
+func (this *HomepageRenderingHandlerSuite) add(articles ...*contracts.Article) {
+	for _, article := range articles {
+		this.site.Add(*article)
+		this.handler.Handle(article)
+	}
 }
 func (this *HomepageRenderingHandlerSuite) handleAndFinalize() error {
-	this.handler.Handle(articleA)
-	this.handler.Handle(articleB)
-	this.handler.Handle(articleB2)
-	this.handler.Handle(articleC)
+	this.add(articleA, articleB, articleB2, articleC, standalonePage)
 	return this.handler.Finalize()
 }
 func (this *HomepageRenderingHandlerSuite) TestNoArticles_NothingToRender() {
-	this.handler.Handle(articleC) // will be filtered out
+	this.add(standalonePage) // pages are left out
 	err := this.handler.Finalize()
 	this.So(err, should.BeNil)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 func (this *HomepageRenderingHandlerSuite) TestFileTemplateRenderedAndWrittenToDisk() {
 	this.renderer.result = "RENDERED"
@@ -128,10 +149,52 @@ func (this *HomepageRenderingHandlerSuite) TestFileTemplateRenderedAndWrittenToD
 
 	this.So(err, should.BeNil)
 	this.assertHandledArticlesRendered()
-	this.So(this.disk.Files, should.Contain, "output/folder")
-	this.So(this.disk.Files, better.Contain, "output/folder/index.html")
-	file := this.disk.Files["output/folder/index.html"]
-	this.So(file.Content(), should.Equal, "RENDERED")
+	this.So(this.disk.Paths(), should.Contain, "output/folder")
+	this.So(this.disk.Paths(), better.Contain, "output/folder/index.html")
+	this.So(this.disk.Content("output/folder/index.html"), should.Equal, "RENDERED")
+}
+func (this *HomepageRenderingHandlerSuite) TestPinnedArticlesListedAboveRecentOnes() {
+	pin := func(slug string, weight int) *contracts.Article {
+		return &contracts.Article{Metadata: contracts.ArticleMetadata{Slug: slug, Title: slug, Date: Date(2023, 7, 1), Pinned: true, Weight: weight}}
+	}
+	this.add(pin("/pinned-y", 0), articleA, pin("/pinned-z", -1), pin("/pinned-x", 0))
+
+	err := this.handler.Finalize()
+
+	this.So(err, should.BeNil)
+	page := this.renderer.rendered.(contracts.RenderedHomePage)
+	this.So(slugsOf(page.Pinned), should.Equal, []string{"/pinned-z", "/pinned-x", "/pinned-y"})
+	this.So(slugsOf(page.Recent), should.Equal, []string{"/a"})
+}
+func (this *HomepageRenderingHandlerSuite) TestCountsLimitRecentArticlesAndTopics() {
+	this.handler = this.newHandler(HomepageCounts{Recent: 2, Topics: 1}, TopicSettings{Minimum: 1, Order: TopicOrderAlphabetical})
+
+	err := this.handleAndFinalize()
+
+	this.So(err, should.BeNil)
+	page := this.renderer.rendered.(contracts.RenderedHomePage)
+	this.So(page.ProminentTopics, should.Equal, []contracts.RenderedTopic{{Topic: "topic-b", Count: 2}})
+	this.So(slugsOf(page.Recent), should.Equal, []string{"/c", "/b"})
+}
+func (this *HomepageRenderingHandlerSuite) TestProminentTopicsByPopularity() {
+	this.handler = this.newHandler(HomepageCounts{Recent: 10, Topics: 30}, TopicSettings{Minimum: 1, Order: TopicOrderPopularity})
+
+	err := this.handleAndFinalize()
+
+	this.So(err, should.BeNil)
+	page := this.renderer.rendered.(contracts.RenderedHomePage)
+	this.So(page.ProminentTopics, should.Equal, []contracts.RenderedTopic{
+		{Topic: "topic-b", Count: 2}, {Topic: "topic-a", Count: 1}, {Topic: "topic-c", Count: 1},
+	})
+}
+func (this *HomepageRenderingHandlerSuite) TestProminentTopicsLeaveOutThoseBelowTheMinimum() {
+	this.handler = this.newHandler(HomepageCounts{Recent: 10, Topics: 30}, TopicSettings{Minimum: 2, Order: TopicOrderAlphabetical})
+
+	err := this.handleAndFinalize()
+
+	this.So(err, should.BeNil)
+	page := this.renderer.rendered.(contracts.RenderedHomePage)
+	this.So(page.ProminentTopics, should.Equal, []contracts.RenderedTopic{{Topic: "topic-b", Count: 2}})
 }
 func (this *HomepageRenderingHandlerSuite) TestRenderErrorReturned() {
 	renderErr := errors.New("boink")
@@ -140,7 +203,7 @@ func (this *HomepageRenderingHandlerSuite) TestRenderErrorReturned() {
 	err := this.handleAndFinalize()
 
 	this.So(err, should.WrapError, renderErr)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 func (this *HomepageRenderingHandlerSuite) TestMkdirAllErrorReturned() {
 	this.renderer.result = "RENDERED"
@@ -150,7 +213,7 @@ func (this *HomepageRenderingHandlerSuite) TestMkdirAllErrorReturned() {
 	err := this.handleAndFinalize()
 
 	this.So(err, should.WrapError, mkdirErr)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 func (this *HomepageRenderingHandlerSuite) TestWriteFileErrorReturned() {
 	this.renderer.result = "RENDERED"
@@ -160,7 +223,7 @@ func (this *HomepageRenderingHandlerSuite) TestWriteFileErrorReturned() {
 	err := this.handleAndFinalize()
 
 	this.So(err, should.WrapError, writeFileErr)
-	this.So(this.disk.Files, should.NOT.Contain, "output/folder/index.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "output/folder/index.html")
 }
 
 func TestLeaderboardSuite(t *testing.T) {
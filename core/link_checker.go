@@ -0,0 +1,189 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// LinkChecker scans the html files produced by a build for internal links
+// (href and src attributes, including #fragments) that don't resolve to
+// anything the build wrote.
+type LinkChecker struct {
+	disk     contracts.ReadFile
+	root     string
+	basePath string
+	produced map[string]struct{}
+	allowed  []string
+}
+
+// NewLinkChecker takes produced as reported by TrackingFileSystem.Produced
+// (paths relative to root). Links to paths starting with any of the allowed
+// prefixes (relative to the base path, e.g. "/img/") are assumed to refer
+// to files placed in the target by hand.
+func NewLinkChecker(disk contracts.ReadFile, root, basePath string, produced map[string]struct{}, allowed []string) *LinkChecker {
+	this := &LinkChecker{
+		disk:     disk,
+		root:     root,
+		basePath: strings.TrimSuffix(basePath, "/"),
+		produced: make(map[string]struct{}, len(produced)),
+		allowed:  allowed,
+	}
+	for rel := range produced {
+		this.produced[filepath.ToSlash(rel)] = struct{}{}
+	}
+	return this
+}
+
+// Check lists every broken link, ordered by page and then by position
+// within the page.
+func (this *LinkChecker) Check() (broken []BrokenLink, err error) {
+	links := make(map[string][]string)
+	anchors := make(map[string]map[string]struct{})
+	var pages []string
+	for rel := range this.produced {
+		if path.Ext(rel) == ".html" {
+			pages = append(pages, rel)
+		}
+	}
+	slices.Sort(pages)
+	for _, page := range pages {
+		content, readErr := this.disk.ReadFile(filepath.Join(this.root, filepath.FromSlash(page)))
+		if readErr != nil {
+			err = errors.Join(err, readErr)
+			continue
+		}
+		links[page] = attributeValues(linkAttribute, content)
+		anchors[page] = make(map[string]struct{})
+		for _, anchor := range attributeValues(anchorAttribute, content) {
+			anchors[page][anchor] = struct{}{}
+		}
+	}
+	for _, page := range pages {
+		for _, link := range links[page] {
+			if reason := this.resolve(page, link, anchors); reason != "" {
+				broken = append(broken, BrokenLink{Page: page, Link: link, Reason: reason})
+			}
+		}
+	}
+	return broken, err
+}
+
+// resolve explains why the link (found on page) is broken, or returns ""
+// when it isn't (or isn't internal).
+func (this *LinkChecker) resolve(page, link string, anchors map[string]map[string]struct{}) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return "malformed url"
+	}
+	if parsed.Scheme != "" || parsed.Host != "" || parsed.Opaque != "" {
+		return ""
+	}
+	target := page
+	if parsed.Path != "" {
+		location, found := this.locate(page, parsed.Path)
+		if location == "" {
+			return "outside base path"
+		}
+		if !found && this.isAllowed(location) {
+			return ""
+		}
+		if !found {
+			return "no such page"
+		}
+		target = location
+	}
+	if parsed.Fragment == "" || parsed.Fragment == "top" || path.Ext(target) != ".html" {
+		return ""
+	}
+	if _, found := anchors[target][parsed.Fragment]; !found {
+		return reasonNoSuchAnchor
+	}
+	return ""
+}
+
+// locate finds the produced file that a url path refers to (relative
+// links being relative to the page's own url). An empty location means
+// that an absolute path falls outside of the base path.
+func (this *LinkChecker) locate(page, urlPath string) (location string, found bool) {
+	if strings.HasPrefix(urlPath, "/") {
+		trimmed, ok := strings.CutPrefix(urlPath, this.basePath)
+		if !ok || (trimmed != "" && !strings.HasPrefix(trimmed, "/")) {
+			return "", false
+		}
+		urlPath = "/" + strings.TrimPrefix(trimmed, "/")
+	} else {
+		slash := strings.HasSuffix(urlPath, "/")
+		urlPath = path.Join("/", path.Dir(page), urlPath)
+		if slash {
+			urlPath += "/"
+		}
+	}
+	rel := strings.Trim(path.Clean(urlPath), "/")
+	index := path.Join(rel, "index.html")
+	if _, found = this.produced[index]; found {
+		return index, true
+	}
+	if rel == "" || strings.HasSuffix(urlPath, "/") {
+		return index, false
+	}
+	_, found = this.produced[rel]
+	return rel, found
+}
+
+func (this *LinkChecker) isAllowed(location string) bool {
+	return isAllowedLink(this.allowed, location)
+}
+
+// isAllowedLink reports whether the file at rel (relative to the target,
+// with forward slashes) falls under any of the allowed url path prefixes
+// (see -allow-links), and so was placed in the target by hand.
+func isAllowedLink(allowed []string, rel string) bool {
+	return slices.ContainsFunc(allowed, func(prefix string) bool {
+		return strings.HasPrefix("/"+rel, prefix)
+	})
+}
+
+func attributeValues(pattern *regexp.Regexp, content []byte) (values []string) {
+	for _, match := range pattern.FindAllSubmatch(content, -1) {
+		value := match[1]
+		if value == nil {
+			value = match[2]
+		}
+		values = append(values, html.UnescapeString(string(value)))
+	}
+	return values
+}
+
+var (
+	linkAttribute   = regexp.MustCompile(`(?i)\s(?:href|src)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+	anchorAttribute = regexp.MustCompile(`(?i)\s(?:id|name)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// reasonNoSuchAnchor marks a link to a page that exists, but has no such
+// anchor (e.g. a topic with too few articles to be listed on the topics
+// page), which is only worth a warning.
+const reasonNoSuchAnchor = "no such anchor"
+
+type BrokenLink struct {
+	Page   string
+	Link   string
+	Reason string
+}
+
+func (this BrokenLink) Error() string {
+	return fmt.Sprintf("%s: [%s] on page [%s] (%s)", errBrokenLink, this.Link, this.Page, this.Reason)
+}
+func (this BrokenLink) Unwrap() error {
+	return errBrokenLink
+}
+
+var errBrokenLink = errors.New("broken link")
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestLinkCheckerFixture(t *testing.T) {
+	suite.Run(&LinkCheckerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type LinkCheckerFixture struct {
+	*suite.T
+
+	disk     *FakeFileSystem
+	basePath string
+	allowed  []string
+}
+
+func (this *LinkCheckerFixture) Setup() {
+	this.disk = NewFakeFileSystem()
+	this.page("index.html", `<a href="/a/">A</a> <a href='/b/#section'>B</a> <img src="/style/logo.png">`)
+	this.page("a/index.html", `<h1 id="top-heading">A</h1> <a href="#top-heading">up</a> <a href="../b/">B</a>`)
+	this.page("b/index.html", `<h2 id="section">B</h2> <a href="https://example.com/missing/">elsewhere</a> <a href="mailto:me@example.com">me</a>`)
+	this.page("style/logo.png", "PNG")
+}
+
+func (this *LinkCheckerFixture) page(rel, content string) {
+	this.disk.Write("rendered/"+rel, content)
+}
+func (this *LinkCheckerFixture) check() ([]BrokenLink, error) {
+	produced := map[string]struct{}{"a": {}, "b": {}, "style": {}}
+	for _, path := range this.disk.Paths() {
+		if rel, found := strings.CutPrefix(path, "rendered/"); found {
+			produced[rel] = struct{}{}
+		}
+	}
+	return NewLinkChecker(this.disk, "rendered", this.basePath, produced, this.allowed).Check()
+}
+
+func (this *LinkCheckerFixture) TestValidLinks() {
+	broken, err := this.check()
+
+	this.So(err, should.BeNil)
+	this.So(broken, should.BeEmpty)
+}
+
+func (this *LinkCheckerFixture) TestBrokenLinks() {
+	this.page("c/index.html", `
+		<a href="/old-slug/">gone</a>
+		<a href="/a/#missing">no anchor</a>
+		<a href="#nowhere">no local anchor</a>
+		<a href="../d/">relative</a>
+		<img src="/style/missing.png">
+		<a href="/a/?query=1">fine</a>`)
+
+	broken, err := this.check()
+
+	this.So(err, should.BeNil)
+	this.So(broken, should.Equal, []BrokenLink{
+		{Page: "c/index.html", Link: "/old-slug/", Reason: "no such page"},
+		{Page: "c/index.html", Link: "/a/#missing", Reason: "no such anchor"},
+		{Page: "c/index.html", Link: "#nowhere", Reason: "no such anchor"},
+		{Page: "c/index.html", Link: "../d/", Reason: "no such page"},
+		{Page: "c/index.html", Link: "/style/missing.png", Reason: "no such page"},
+	})
+	this.So(broken[0], should.WrapError, errBrokenLink)
+	this.So(broken[0].Error(), should.Equal, "broken link: [/old-slug/] on page [c/index.html] (no such page)")
+}
+
+func (this *LinkCheckerFixture) TestEscapedAttributes() {
+	this.page("c/index.html", `<a href="/a/?x=1&amp;y=2">escaped</a> <a href="/%62/">encoded</a>`)
+
+	broken, err := this.check()
+
+	this.So(err, should.BeNil)
+	this.So(broken, should.BeEmpty)
+}
+
+func (this *LinkCheckerFixture) TestBasePath() {
+	this.basePath = "/blog"
+	this.page("index.html", `<a href="/blog/a/">A</a> <a href="/blog">home</a> <a href="/a/">outside</a> <a href="/blogger/">outside</a>`)
+
+	broken, err := this.check()
+
+	this.So(err, should.BeNil)
+	this.So(broken, should.Equal, []BrokenLink{
+		{Page: "index.html", Link: "/a/", Reason: "outside base path"},
+		{Page: "index.html", Link: "/blogger/", Reason: "outside base path"},
+	})
+}
+
+func (this *LinkCheckerFixture) TestAllowedPrefixes() {
+	this.basePath = "/blog"
+	this.allowed = []string{"/img/", "/favicon.ico"}
+	this.page("index.html", `
+		<img src="/blog/img/x.png">
+		<img src="img/y.png">
+		<link href="/blog/favicon.ico">
+		<a href="/blog/imgs/">not allowed</a>
+		<img src="/img/x.png">`)
+
+	broken, err := this.check()
+
+	this.So(err, should.BeNil)
+	this.So(broken, should.Equal, []BrokenLink{
+		{Page: "index.html", Link: "/blog/imgs/", Reason: "no such page"},
+		{Page: "index.html", Link: "/img/x.png", Reason: "outside base path"},
+	})
+}
+
+func (this *LinkCheckerFixture) TestReadFailure() {
+	readErr := errors.New("boink")
+	this.disk.ErrReadFile["rendered/a/index.html"] = readErr
+
+	_, err := this.check()
+
+	this.So(err, should.WrapError, readErr)
+}
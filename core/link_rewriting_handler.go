@@ -0,0 +1,42 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// LinkRewritingHandler points links between content files (e.g.
+// [part two](./part-two.md)) at the linked article's slug. It relies on
+// an index of every article, so it must follow the pipeline's barrier.
+type LinkRewritingHandler struct {
+	index *ArticleIndex
+}
+
+func NewLinkRewritingHandler(index *ArticleIndex) *LinkRewritingHandler {
+	return &LinkRewritingHandler{index: index}
+}
+
+func (this *LinkRewritingHandler) Handle(article *contracts.Article) {
+	var err error
+	article.Content.Converted = hrefAttribute.ReplaceAllStringFunc(article.Content.Converted, func(match string) string {
+		destination := html.UnescapeString(hrefAttribute.FindStringSubmatch(match)[1])
+		resolved, resolveErr := this.index.Resolve(article.Source.Path, destination)
+		if resolveErr != nil {
+			err = errors.Join(err, resolveErr)
+			return match
+		}
+		if resolved == destination {
+			return match
+		}
+		return fmt.Sprintf(` href="%s"`, html.EscapeString(resolved))
+	})
+	if err != nil {
+		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, err)
+	}
+}
+
+var hrefAttribute = regexp.MustCompile(`\shref="([^"]*)"`)
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestLinkRewritingHandlerFixture(t *testing.T) {
+	suite.Run(&LinkRewritingHandlerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type LinkRewritingHandlerFixture struct {
+	*suite.T
+
+	handler *LinkRewritingHandler
+	article *contracts.Article
+}
+
+func (this *LinkRewritingHandlerFixture) Setup() {
+	index := NewArticleIndex()
+	index.Add(contracts.Article{
+		Source:   contracts.ArticleSource{Path: "content/b.md"},
+		Metadata: contracts.ArticleMetadata{Slug: "/article-b/"},
+	})
+	this.handler = NewLinkRewritingHandler(index)
+	this.article = &contracts.Article{Source: contracts.ArticleSource{Path: "content/a.md"}}
+}
+
+func (this *LinkRewritingHandlerFixture) TestLinksToContentFilesRewritten() {
+	this.article.Content.Converted = `<p><a href="./b.md#part-2" title="B">B</a> and <a href="https://example.com/?a=1&amp;b=2">elsewhere</a></p>`
+
+	this.handler.Handle(this.article)
+
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.article.Content.Converted, should.Equal,
+		`<p><a href="/article-b/#part-2" title="B">B</a> and <a href="https://example.com/?a=1&amp;b=2">elsewhere</a></p>`)
+}
+
+func (this *LinkRewritingHandlerFixture) TestWikiLinksRewritten() {
+	this.article.Content.Converted = `<a href="wiki:article-b">B</a> <a href="wiki:article-c">C</a>`
+
+	this.handler.Handle(this.article)
+
+	this.So(this.article.Content.Converted, should.Equal, `<a href="/article-b/">B</a> <a href="wiki:article-c">C</a>`)
+	this.So(this.article.Error.Error(), should.Equal,
+		"[content/a.md] unresolved article link: [wiki:article-c] (no such article)")
+}
+
+func (this *LinkRewritingHandlerFixture) TestEveryUnresolvedLinkReported() {
+	this.article.Content.Converted = `<a href="c.md">C</a> <a href="b.md">B</a> <a href="d.md">D</a>`
+
+	this.handler.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errUnresolvedArticleLink)
+	this.So(this.article.Error.Error(), should.Equal,
+		"[content/a.md] unresolved article link: [c.md] (no such article)\n"+
+			"unresolved article link: [d.md] (no such article)")
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestLoggingFixture(t *testing.T) {
+	suite.Run(&LoggingFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type LoggingFixture struct {
+	*suite.T
+	output *bytes.Buffer
+}
+
+func (this *LoggingFixture) Setup() {
+	this.output = new(bytes.Buffer)
+}
+
+func (this *LoggingFixture) TestTextFormat() {
+	NewLogger(this.output, LogFormatText, slog.LevelInfo).Info("hello", "path", "a.md")
+
+	this.So(this.output.String(), should.Contain, `level=INFO msg=hello path=a.md`)
+}
+
+func (this *LoggingFixture) TestJSONFormat() {
+	NewLogger(this.output, LogFormatJSON, slog.LevelInfo).Info("hello", "path", "a.md")
+
+	var record map[string]any
+	this.So(json.Unmarshal(this.output.Bytes(), &record), should.BeNil)
+	this.So(record["msg"], should.Equal, "hello")
+	this.So(record["path"], should.Equal, "a.md")
+}
+
+func (this *LoggingFixture) TestRecordsBelowLevelDiscarded() {
+	logger := NewLogger(this.output, LogFormatText, slog.LevelWarn)
+	logger.Info("quiet")
+	logger.Warn("loud")
+
+	this.So(this.output.String(), should.NOT.Contain, "quiet")
+	this.So(this.output.String(), should.Contain, "loud")
+}
+
+// newTestLogger logs every level as text, omitting timestamps so that
+// output can be compared exactly.
+func newTestLogger(output io.Writer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(output, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, attribute slog.Attr) slog.Attr {
+			if len(groups) == 0 && attribute.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attribute
+		},
+	}))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer/html"
 )
 
@@ -17,6 +18,11 @@ func NewGoldmarkMarkdownConverter() *GoldmarkMarkdownConverter {
 	return &GoldmarkMarkdownConverter{
 		buffer: new(bytes.Buffer),
 		converter: goldmark.New(
+			// Headings get ids (e.g. "## Setup" gets id="setup"), so that
+			// links may point at them.
+			goldmark.WithParserOptions(
+				parser.WithAutoHeadingID(),
+			),
 			goldmark.WithRendererOptions(
 				html.WithUnsafe(),
 			),
@@ -24,6 +30,7 @@ func NewGoldmarkMarkdownConverter() *GoldmarkMarkdownConverter {
 				extension.GFM,
 				extension.DefinitionList,
 				extension.Footnote,
+				wikiLinks{},
 			),
 		),
 	}
@@ -42,6 +42,15 @@ func (this *GoldmarkMarkdownConverterFixture) TestRawHTMLIsRenderedForTrustedArt
 	this.So(output, should.Contain, `<span class="callout">Styled article content</span>`)
 }
 
+func (this *GoldmarkMarkdownConverterFixture) TestWikiLinks() {
+	output, err := this.converter.Convert("See [[other-slug]], [[other-slug#part|the <other> one]] and [a link](x.md), but not `[[code]]` or [[ ]].")
+
+	this.So(err, should.BeNil)
+	this.So(output, should.Equal, `<p>See <a href="wiki:other-slug">other-slug</a>, `+
+		`<a href="wiki:other-slug#part">the &lt;other&gt; one</a> and <a href="x.md">a link</a>, `+
+		"but not <code>[[code]]</code> or [[ ]].</p>\n")
+}
+
 const MARKDOWN_INPUT = `
 # H1
 
@@ -91,10 +100,10 @@ That's some text with a footnote.[^1]
 
 `
 
-const EXPECTED_HTML_OUTPUT = `<h1>H1</h1>
-<h2>H2</h2>
-<h3>H3</h3>
-<h4>H4</h4>
+const EXPECTED_HTML_OUTPUT = `<h1 id="h1">H1</h1>
+<h2 id="h2">H2</h2>
+<h3 id="h3">H3</h3>
+<h4 id="h4">H4</h4>
 <ul>
 <li>a</li>
 <li>b</li>
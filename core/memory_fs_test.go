@@ -1,127 +1,113 @@
 package core
 
 import (
-	"io/fs"
 	"os"
 	"path/filepath"
-	"sort"
-	"strings"
-	"sync"
-	"time"
+	"slices"
 
 	"github.com/mdw-tools/hugoinho/contracts"
+	"github.com/mdw-tools/hugoinho/io"
 )
 
-type InMemoryFileSystem struct {
-	ModTime      time.Time
-	lock         *sync.RWMutex
-	Files        map[string]*MemoryFile
+// FakeFileSystem is an io.Memory whose operations can be made to fail
+// (by path), for testing how each failure is handled.
+type FakeFileSystem struct {
+	*io.Memory
 	ErrReadFile  map[string]error
 	ErrWriteFile map[string]error
 	ErrMkdirAll  map[string]error
+	ErrRemoveAll map[string]error
+	ErrRename    map[string]error
 	ErrWalkFunc  map[string]error
 }
 
-func NewInMemoryFileSystem() *InMemoryFileSystem {
-	return &InMemoryFileSystem{
-		ModTime:      time.Now(),
-		lock:         new(sync.RWMutex),
-		Files:        make(map[string]*MemoryFile),
+func NewFakeFileSystem() *FakeFileSystem {
+	return &FakeFileSystem{
+		Memory:       io.NewMemory(),
 		ErrReadFile:  make(map[string]error),
 		ErrWriteFile: make(map[string]error),
 		ErrMkdirAll:  make(map[string]error),
+		ErrRemoveAll: make(map[string]error),
+		ErrRename:    make(map[string]error),
 		ErrWalkFunc:  make(map[string]error),
 	}
 }
 
-func (this *InMemoryFileSystem) ReadFile(path string) ([]byte, error) {
-	this.lock.RLock()
-	defer this.lock.RUnlock()
-	err := this.ErrReadFile[path]
-	if err != nil {
+func (this *FakeFileSystem) ReadFile(path string) ([]byte, error) {
+	if err := this.ErrReadFile[path]; err != nil {
 		return nil, err
 	}
-	return this.Files[path].content, nil
+	return this.Memory.ReadFile(path)
 }
-
-func (this *InMemoryFileSystem) WriteFile(path string, content []byte, perm os.FileMode) error {
-	this.lock.Lock()
-	defer this.lock.Unlock()
-	err := this.ErrWriteFile[path]
-	if err != nil {
+func (this *FakeFileSystem) WriteFile(path string, content []byte, perm os.FileMode) error {
+	if err := this.ErrWriteFile[path]; err != nil {
 		return err
 	}
-	this.Files[path] = &MemoryFile{
-		content: content,
-		name:    filepath.Base(path),
-		mode:    perm,
-		modTime: this.ModTime,
+	return this.Memory.WriteFile(path, content, perm)
+}
+func (this *FakeFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	if err := this.ErrMkdirAll[path]; err != nil {
+		return err
 	}
-	return nil
+	return this.Memory.MkdirAll(path, perm)
 }
-
-func (this *InMemoryFileSystem) MkdirAll(path string, perm os.FileMode) error {
-	this.lock.Lock()
-	defer this.lock.Unlock()
-	err := this.ErrMkdirAll[path]
-	if err != nil {
+func (this *FakeFileSystem) RemoveAll(path string) error {
+	if err := this.ErrRemoveAll[path]; err != nil {
 		return err
 	}
-	components := strings.Split(path, string(os.PathSeparator))
-	for x := 1; x < len(components)+1; x++ {
-		p := strings.Join(components[:x], string(os.PathSeparator))
-		this.Files[p] = &MemoryFile{
-			name:    filepath.Base(p),
-			mode:    perm,
-			modTime: this.ModTime,
-			isDir:   true,
-		}
+	return this.Memory.RemoveAll(path)
+}
+func (this *FakeFileSystem) Rename(oldPath, newPath string) error {
+	if err := this.ErrRename[oldPath]; err != nil {
+		return err
 	}
-	return nil
+	return this.Memory.Rename(oldPath, newPath)
 }
-
-func (this *InMemoryFileSystem) Walk(root string) (result chan contracts.FileSystemEntry) {
+func (this *FakeFileSystem) Walk(root string) (result chan contracts.FileSystemEntry) {
 	result = make(chan contracts.FileSystemEntry)
 	go func() {
-		this.lock.RLock()
-		defer this.lock.RUnlock()
 		defer close(result)
-		root = filepath.Clean(root)
-		var paths []string
-		for path := range this.Files {
-			if path == root || strings.HasPrefix(path, root+"/") {
-				paths = append(paths, path)
-			}
-		}
-		sort.Strings(paths)
-		for _, path := range paths {
-			result <- contracts.FileSystemEntry{
-				Root:     root,
-				Path:     path,
-				DirEntry: this.Files[path],
-				Error:    this.ErrWalkFunc[path],
+		for entry := range this.Memory.Walk(root) {
+			if err := this.ErrWalkFunc[entry.Path]; err != nil {
+				entry.Error = err
 			}
+			result <- entry
 		}
 	}()
 	return result
 }
 
-///////////////////////////////////////////////////////////////////
+// Write sets up a file (and its parent directories) for a test.
+func (this *FakeFileSystem) Write(path, content string) {
+	_ = this.Memory.MkdirAll(filepath.Dir(path), 0755)
+	_ = this.Memory.WriteFile(path, []byte(content), 0644)
+}
 
-type MemoryFile struct {
-	content []byte
-	name    string
-	mode    os.FileMode
-	modTime time.Time
-	isDir   bool
+// Content is what the file at path contains ("" if there is no such file).
+func (this *FakeFileSystem) Content(path string) string {
+	content, _ := this.Memory.ReadFile(path)
+	return string(content)
 }
 
-func (this *MemoryFile) Content() string            { return string(this.content) }
-func (this *MemoryFile) Name() string               { return this.name }
-func (this *MemoryFile) Size() int64                { return int64(len(this.content)) }
-func (this *MemoryFile) Mode() os.FileMode          { return this.mode }
-func (this *MemoryFile) ModTime() time.Time         { return this.modTime }
-func (this *MemoryFile) IsDir() bool                { return this.isDir }
-func (this *MemoryFile) Sys() any                   { panic("NOT NEEDED") }
-func (this *MemoryFile) Type() fs.FileMode          { return this.mode }
-func (this *MemoryFile) Info() (fs.FileInfo, error) { return this, nil }
+// Paths lists every file and directory, in lexical order.
+func (this *FakeFileSystem) Paths() (paths []string) {
+	for _, root := range []string{".", string(filepath.Separator)} {
+		for entry := range this.Memory.Walk(root) {
+			if entry.Error == nil && entry.Path != root {
+				paths = append(paths, entry.Path)
+			}
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Files lists only the files (not the directories), in lexical order.
+func (this *FakeFileSystem) Files() (files []string) {
+	for _, path := range this.Paths() {
+		if info, err := this.Memory.Stat(path); err == nil && !info.Mode().IsDir() {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/mdw-tools/hugoinho/contracts"
 )
@@ -21,50 +23,60 @@ type MetadataParser struct {
 	parsedDraft  bool
 	parsedDate   bool
 	parsedTopics bool
+	parsedSeries bool
+	parsedPart   bool
+	parsedKind   bool
+
+	parsedAliases bool
+	parsedPinned  bool
+	parsedWeight  bool
 }
 
 func NewMetadataParser(lines []string) *MetadataParser {
 	return &MetadataParser{lines: lines}
 }
 
-func (this *MetadataParser) Parse() error {
-	for _, line := range this.lines {
+// Parse reads every metadata line, joining all of the problems found
+// (each prefixed with the line and column of the offending value) into
+// the returned error.
+func (this *MetadataParser) Parse() (err error) {
+	for index, line := range this.lines {
 		key, value := divide(line, ":")
-
-		switch key {
-		case "title":
-			err := this.parseTitle(value)
-			if err != nil {
-				return err
-			}
-		case "intro":
-			err := this.parseIntro(value)
-			if err != nil {
-				return err
-			}
-		case "slug":
-			err := this.parseSlug(value)
-			if err != nil {
-				return err
-			}
-		case "draft":
-			err := this.parseDraft(value)
-			if err != nil {
-				return err
-			}
-		case "date":
-			err := this.parseDate(value)
-			if err != nil {
-				return err
-			}
-		case "topics":
-			err := this.parseTopics(value)
-			if err != nil {
-				return err
-			}
+		lineErr := this.parseLine(key, value)
+		if lineErr != nil {
+			err = errors.Join(err, fmt.Errorf("line %d, column %d: %w", index+1, valueColumn(line), lineErr))
 		}
 	}
+	return err
+}
 
+func (this *MetadataParser) parseLine(key, value string) error {
+	switch key {
+	case "title":
+		return this.parseTitle(value)
+	case "intro":
+		return this.parseIntro(value)
+	case "slug":
+		return this.parseSlug(value)
+	case "draft":
+		return this.parseDraft(value)
+	case "date":
+		return this.parseDate(value)
+	case "topics":
+		return this.parseTopics(value)
+	case "series":
+		return this.parseSeries(value)
+	case "series-part":
+		return this.parseSeriesPart(value)
+	case "kind":
+		return this.parseKind(value)
+	case "aliases":
+		return this.parseAliases(value)
+	case "pinned":
+		return this.parsePinned(value)
+	case "weight":
+		return this.parseWeight(value)
+	}
 	return nil
 }
 
@@ -94,6 +106,18 @@ func (this *MetadataParser) parseSlug(value string) error {
 	if value == "" {
 		return errBlankMetadataSlug
 	}
+	if err := checkSlug(value); err != nil {
+		return err
+	}
+	this.parsed.Slug = value
+	this.parsedSlug = true
+	return nil
+}
+
+// checkSlug applies the rules for slugs (and aliases, which are old
+// slugs): a clean, lowercase, unescaped path that doesn't collide with
+// any page or file that the build generates itself.
+func checkSlug(value string) error {
 	if strings.ToLower(value) != value {
 		return errInvalidMetadataSlug
 	}
@@ -106,6 +130,15 @@ func (this *MetadataParser) parseSlug(value string) error {
 	if value == "/topics" || strings.HasPrefix(value, "/topics/") {
 		return errInvalidMetadataSlug
 	}
+	if value == "/series" || strings.HasPrefix(value, "/series/") {
+		return errInvalidMetadataSlug
+	}
+	if value == contracts.NotFoundSlug {
+		return errInvalidMetadataSlug
+	}
+	if key := strings.Trim(value, "/"); key == NetlifyRedirectsFile || key == NginxRedirectsFile {
+		return errInvalidMetadataSlug
+	}
 	if path.Clean(value) != strings.TrimSuffix(value, "/") {
 		return errInvalidMetadataSlug
 	}
@@ -116,8 +149,6 @@ func (this *MetadataParser) parseSlug(value string) error {
 	if parsed.Path != parsed.EscapedPath() {
 		return fmt.Errorf("%w: [%s]", errInvalidMetadataSlug, value)
 	}
-	this.parsed.Slug = value
-	this.parsedSlug = true
 	return nil
 }
 func (this *MetadataParser) parseDraft(value string) error {
@@ -148,7 +179,7 @@ func (this *MetadataParser) parseDate(value string) error {
 	}
 	parsed, err := time.Parse("2006-01-02", value)
 	if err != nil {
-		return fmt.Errorf("%w with value: [%s] err: %v", errInvalidMetadataDate, value, err)
+		return fmt.Errorf("%w: [%s] (expected YYYY-MM-DD)", errInvalidMetadataDate, value)
 	}
 	this.parsed.Date = parsed
 	this.parsedDate = true
@@ -173,6 +204,116 @@ func (this *MetadataParser) parseTopics(value string) error {
 	this.parsedTopics = true
 	return nil
 }
+func (this *MetadataParser) parseSeries(value string) error {
+	if this.parsedSeries {
+		return errDuplicateMetadataSeries
+	}
+	if value == "" {
+		return errBlankMetadataSeries
+	}
+	if strings.ContainsRune(value, ' ') || !isValidTopic(value) {
+		return fmt.Errorf("%w: [%s]", errInvalidMetadataSeries, value)
+	}
+	this.parsed.Series = value
+	this.parsedSeries = true
+	return nil
+}
+func (this *MetadataParser) parseSeriesPart(value string) error {
+	if this.parsedPart {
+		return errDuplicateMetadataSeriesPart
+	}
+	if value == "" {
+		return errBlankMetadataSeriesPart
+	}
+	part, err := strconv.Atoi(value)
+	if err != nil || part < 1 {
+		return fmt.Errorf("%w: [%s] (expected a whole number from 1)", errInvalidMetadataSeriesPart, value)
+	}
+	this.parsed.SeriesPart = part
+	this.parsedPart = true
+	return nil
+}
+func (this *MetadataParser) parseKind(value string) error {
+	if this.parsedKind {
+		return errDuplicateMetadataKind
+	}
+	switch value {
+	case contracts.KindArticle, contracts.KindPage, contracts.KindNotFound:
+		this.parsed.Kind = value
+		this.parsedKind = true
+		return nil
+	case "":
+		return errBlankMetadataKind
+	default:
+		return fmt.Errorf("%w: [%s] (expected %s, %s or %s)", errInvalidMetadataKind, value,
+			contracts.KindArticle, contracts.KindPage, contracts.KindNotFound)
+	}
+}
+func (this *MetadataParser) parseAliases(value string) error {
+	if this.parsedAliases {
+		return errDuplicateMetadataAliases
+	}
+	if value == "" {
+		return errBlankMetadataAliases
+	}
+	unique := make(map[string]struct{})
+	aliases := strings.Fields(value)
+	for _, alias := range aliases {
+		if err := checkSlug(alias); err != nil {
+			return fmt.Errorf("%w: [%s] (%w)", errInvalidMetadataAliases, alias, err)
+		}
+		unique[slugKey(alias)] = struct{}{}
+	}
+	if len(unique) != len(aliases) {
+		return fmt.Errorf("%w: [%s] (repeated values)", errInvalidMetadataAliases, value)
+	}
+	this.parsed.Aliases = aliases
+	this.parsedAliases = true
+	return nil
+}
+func (this *MetadataParser) parsePinned(value string) error {
+	if this.parsedPinned {
+		return errDuplicateMetadataPinned
+	}
+	switch value {
+	case "true":
+		this.parsed.Pinned = true
+	case "false":
+		this.parsed.Pinned = false
+	case "":
+		return errBlankMetadataPinned
+	default:
+		return fmt.Errorf("%w: [%s]", errInvalidMetadataPinned, value)
+	}
+	this.parsedPinned = true
+	return nil
+}
+func (this *MetadataParser) parseWeight(value string) error {
+	if this.parsedWeight {
+		return errDuplicateMetadataWeight
+	}
+	if value == "" {
+		return errBlankMetadataWeight
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%w: [%s] (expected a whole number)", errInvalidMetadataWeight, value)
+	}
+	this.parsed.Weight = weight
+	this.parsedWeight = true
+	return nil
+}
+
+// valueColumn is the (1-based) column at which the value following the
+// key's colon begins.
+func valueColumn(line string) int {
+	_, after, found := strings.Cut(line, ":")
+	if !found {
+		return 1
+	}
+	offset := len(line) - len(strings.TrimLeft(after, " \t"))
+	return utf8.RuneCountInString(line[:offset]) + 1
+}
 
 func isValidTopic(topic string) bool {
 	for _, c := range topic {
@@ -198,15 +339,58 @@ var (
 	errDuplicateMetadataDate   = errors.New("duplicate metadata date")
 	errDuplicateMetadataTopics = errors.New("duplicate metadata topics")
 
+	errDuplicateMetadataSeries     = errors.New("duplicate metadata series")
+	errDuplicateMetadataSeriesPart = errors.New("duplicate metadata series-part")
+	errDuplicateMetadataKind       = errors.New("duplicate metadata kind")
+	errDuplicateMetadataAliases    = errors.New("duplicate metadata aliases")
+	errDuplicateMetadataPinned     = errors.New("duplicate metadata pinned")
+	errDuplicateMetadataWeight     = errors.New("duplicate metadata weight")
+
 	errInvalidMetadataSlug   = errors.New("invalid metadata slug")
 	errInvalidMetadataDraft  = errors.New("invalid metadata draft")
 	errInvalidMetadataDate   = errors.New("invalid metadata date")
 	errInvalidMetadataTopics = errors.New("invalid metadata topics")
 
-	errRepeatedMetadataSlug = errors.New("repeated metadata slug")
+	errInvalidMetadataSeries     = errors.New("invalid metadata series")
+	errInvalidMetadataSeriesPart = errors.New("invalid metadata series-part")
+	errInvalidMetadataKind       = errors.New("invalid metadata kind")
+	errInvalidMetadataAliases    = errors.New("invalid metadata aliases")
+	errInvalidMetadataPinned     = errors.New("invalid metadata pinned")
+	errInvalidMetadataWeight     = errors.New("invalid metadata weight")
+
+	errRepeatedMetadataSlug       = errors.New("repeated metadata slug")
+	errRepeatedMetadataSeriesPart = errors.New("repeated metadata series-part")
+	errRepeatedMetadataAlias      = errors.New("repeated metadata alias")
 
 	errBlankMetadataSlug  = errors.New("blank metadata slug")
 	errBlankMetadataDraft = errors.New("blank metadata draft")
 	errBlankMetadataTitle = errors.New("blank metadata title")
 	errBlankMetadataDate  = errors.New("blank metadata date")
+
+	errBlankMetadataSeries     = errors.New("blank metadata series")
+	errBlankMetadataSeriesPart = errors.New("blank metadata series-part")
+	errBlankMetadataKind       = errors.New("blank metadata kind")
+	errBlankMetadataAliases    = errors.New("blank metadata aliases")
+	errBlankMetadataPinned     = errors.New("blank metadata pinned")
+	errBlankMetadataWeight     = errors.New("blank metadata weight")
+
+	errSeriesPage = errors.New("a page can't be part of a series")
+	errPinnedPage = errors.New("a page can't be pinned (only articles are listed on the homepage)")
+
+	errUnpinnedWeight = errors.New("metadata weight only orders pinned articles")
+
+	errNotFoundSlug             = errors.New("the not-found page has no slug (it is always " + contracts.NotFoundSlug + ")")
+	errRepeatedNotFoundMetadata = errors.New("repeated metadata kind not-found")
+	errNotFoundAliases          = errors.New("the not-found page can't have aliases")
+
+	// Warnings (see contracts.Article.Warn):
+
+	errMissingMetadataIntro = errors.New("missing metadata intro")
+	errLongMetadataTitle    = errors.New("long metadata title")
+	errEmptyContent         = errors.New("empty article content")
+	errLonelyTopic          = errors.New("topic used by too few articles, so omitted from the topics page")
 )
+
+// maxTitleLength is about as long as a title can get before search
+// results and browser tabs start to truncate it.
+const maxTitleLength = 70
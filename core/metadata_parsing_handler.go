@@ -25,6 +25,9 @@ func (this *MetadataParsingHandler) Handle(article *contracts.Article) {
 		return
 	}
 
+	// The lines are split from the untrimmed metadata, so that reported line
+	// numbers (and columns) count from the top of the file.
+	metadata, _, _ = strings.Cut(article.Source.Data, contracts.METADATA_CONTENT_DIVIDER)
 	parser := NewMetadataParser(strings.Split(metadata, "\n"))
 	err := parser.Parse()
 	if err != nil {
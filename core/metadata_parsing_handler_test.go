@@ -132,6 +132,20 @@ func (this *MetadataParserFixture) TestSlugCollidingWithGeneratedTopicsPageWitho
 
 	this.So(this.article.Error, should.WrapError, errInvalidMetadataSlug)
 }
+func (this *MetadataParserFixture) TestSlugCollidingWithGeneratedSeriesPage_Err() {
+	this.appendMetadataWithContent("slug: /series/tutorial/")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataSlug)
+}
+func (this *MetadataParserFixture) TestSlugCollidingWithGeneratedNotFoundPage_Err() {
+	this.appendMetadataWithContent("slug: /404.html")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataSlug)
+}
 func (this *MetadataParserFixture) TestSlugCollidingWithGeneratedArchivesPage_Err() {
 	this.appendMetadataWithContent("slug: /archives/")
 
@@ -247,6 +261,187 @@ func (this *MetadataParserFixture) TestDuplicateTopics_Err() {
 
 	this.So(this.article.Error, should.WrapError, errDuplicateMetadataTopics)
 }
+func (this *MetadataParserFixture) TestAliases() {
+	this.appendMetadataWithContent("aliases: /old/slug/ /older")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.article.Metadata.Aliases, should.Equal, []string{"/old/slug/", "/older"})
+}
+func (this *MetadataParserFixture) TestInvalidAliases_Err() {
+	for _, value := range []string{"/Old/", "/../old/", "/topics/old/", "/404.html", "/_redirects", "/old/ /"} {
+		this.Setup()
+		this.appendMetadataWithContent("aliases: " + value)
+
+		this.parser.Handle(this.article)
+
+		this.So(this.article.Error, should.WrapError, errInvalidMetadataAliases)
+		this.So(this.article.Error, should.WrapError, errInvalidMetadataSlug)
+	}
+}
+func (this *MetadataParserFixture) TestInvalidRepeatedAliases_Err() {
+	this.appendMetadataWithContent("aliases: /old/ /other/ /old")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataAliases)
+	this.So(this.article.Error.Error(), should.EndWith, "(repeated values)")
+}
+func (this *MetadataParserFixture) TestBlankAliases_Err() {
+	this.appendMetadataWithContent("aliases:")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errBlankMetadataAliases)
+}
+func (this *MetadataParserFixture) TestDuplicateAliases_Err() {
+	this.appendMetadataWithContent(
+		"aliases: /a/",
+		"aliases: /b/",
+	)
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataAliases)
+}
+func (this *MetadataParserFixture) TestPinnedWithWeight() {
+	this.appendMetadataWithContent("pinned: true", "weight: -2")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.article.Metadata.Pinned, should.BeTrue)
+	this.So(this.article.Metadata.Weight, should.Equal, -2)
+}
+func (this *MetadataParserFixture) TestInvalidPinned_Err() {
+	this.appendMetadataWithContent("pinned: yes")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataPinned)
+}
+func (this *MetadataParserFixture) TestBlankPinned_Err() {
+	this.appendMetadataWithContent("pinned:")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errBlankMetadataPinned)
+}
+func (this *MetadataParserFixture) TestDuplicatePinned_Err() {
+	this.appendMetadataWithContent("pinned: true", "pinned: false")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataPinned)
+}
+func (this *MetadataParserFixture) TestInvalidWeight_Err() {
+	this.appendMetadataWithContent("weight: heavy")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataWeight)
+}
+func (this *MetadataParserFixture) TestBlankWeight_Err() {
+	this.appendMetadataWithContent("weight:")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errBlankMetadataWeight)
+}
+func (this *MetadataParserFixture) TestDuplicateWeight_Err() {
+	this.appendMetadataWithContent("weight: 1", "weight: 2")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataWeight)
+}
+func (this *MetadataParserFixture) TestInvalidSeries_Err() {
+	for _, value := range []string{"Tutorial", "two words", "what?"} {
+		this.Setup()
+		this.appendMetadataWithContent("series: " + value)
+
+		this.parser.Handle(this.article)
+
+		this.So(this.article.Error, should.WrapError, errInvalidMetadataSeries)
+	}
+}
+func (this *MetadataParserFixture) TestBlankSeries_Err() {
+	this.appendMetadataWithContent("series: ")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errBlankMetadataSeries)
+}
+func (this *MetadataParserFixture) TestDuplicateSeries_Err() {
+	this.appendMetadataWithContent(
+		"series: a",
+		"series: b",
+	)
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataSeries)
+}
+func (this *MetadataParserFixture) TestInvalidSeriesPart_Err() {
+	for _, value := range []string{"0", "-1", "one", "1.5"} {
+		this.Setup()
+		this.appendMetadataWithContent("series-part: " + value)
+
+		this.parser.Handle(this.article)
+
+		this.So(this.article.Error, should.WrapError, errInvalidMetadataSeriesPart)
+	}
+}
+func (this *MetadataParserFixture) TestDuplicateSeriesPart_Err() {
+	this.appendMetadataWithContent(
+		"series-part: 1",
+		"series-part: 2",
+	)
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataSeriesPart)
+}
+func (this *MetadataParserFixture) TestKind() {
+	for value, expected := range map[string]string{
+		"page":      contracts.KindPage,
+		"article":   contracts.KindArticle,
+		"not-found": contracts.KindNotFound,
+	} {
+		this.Setup()
+		this.appendMetadataWithContent("kind: " + value)
+
+		this.parser.Handle(this.article)
+
+		this.So(this.article.Error, should.BeNil)
+		this.So(this.article.Metadata.Kind, should.Equal, expected)
+	}
+}
+func (this *MetadataParserFixture) TestInvalidKind_Err() {
+	this.appendMetadataWithContent("kind: post")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataKind)
+}
+func (this *MetadataParserFixture) TestBlankKind_Err() {
+	this.appendMetadataWithContent("kind: ")
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errBlankMetadataKind)
+}
+func (this *MetadataParserFixture) TestDuplicateKind_Err() {
+	this.appendMetadataWithContent(
+		"kind: page",
+		"kind: page",
+	)
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataKind)
+}
 
 func (this *MetadataParserFixture) TestAllValidAttributes() {
 	this.appendMetadataWithContent(
@@ -256,6 +451,8 @@ func (this *MetadataParserFixture) TestAllValidAttributes() {
 		"draft:  true              ",
 		"date:   2020-02-16        ",
 		"topics: a-a b c           ",
+		"series: go-tutorial       ",
+		"series-part: 2            ",
 	)
 
 	this.parser.Handle(this.article)
@@ -267,4 +464,47 @@ func (this *MetadataParserFixture) TestAllValidAttributes() {
 	this.So(this.article.Metadata.Draft, should.BeTrue)
 	this.So(this.article.Metadata.Date, should.Equal, Date(2020, 2, 16))
 	this.So(this.article.Metadata.Topics, should.Equal, []string{"a-a", "b", "c"})
+	this.So(this.article.Metadata.Series, should.Equal, "go-tutorial")
+	this.So(this.article.Metadata.SeriesPart, should.Equal, 2)
+}
+func (this *MetadataParserFixture) TestLineNumbersCountLeadingBlankLines() {
+	this.article.Source.Path = "content/x.md"
+	this.appendMetadataWithContent(
+		"",
+		"  ",
+		"  title: This is the title",
+		"slug:  /slug/",
+		"date:  2024-13-01",
+	)
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataDate)
+	this.So(this.article.Error.Error(), should.StartWith,
+		"[content/x.md] line 5, column 8: invalid metadata date: [2024-13-01]")
+}
+func (this *MetadataParserFixture) TestEveryProblemReportedWithLineNumbers() {
+	this.article.Source.Path = "content/x.md"
+	this.appendMetadataWithContent(
+		"title: This is the title",
+		"title: This is another title",
+		"slug:  /Invalid",
+		"draft: ",
+		"date:  2024-13-01",
+		"topics: A",
+	)
+
+	this.parser.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, errDuplicateMetadataTitle)
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataSlug)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataDraft)
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataDate)
+	this.So(this.article.Error, should.WrapError, errInvalidMetadataTopics)
+	message := this.article.Error.Error()
+	this.So(message, should.StartWith, "[content/x.md] line 2, column 8: duplicate metadata title\n")
+	this.So(message, should.Contain, "\nline 3, column 8: invalid metadata slug")
+	this.So(message, should.Contain, "\nline 4, column 8: blank metadata draft")
+	this.So(message, should.Contain, "\nline 5, column 8: invalid metadata date: [2024-13-01]")
+	this.So(message, should.Contain, "\nline 6, column 9: invalid metadata topics")
 }
@@ -1,40 +1,121 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/mdw-tools/hugoinho/contracts"
 )
 
 type MetadataValidationHandler struct {
-	slugs map[string]struct{}
+	slugs    map[string]struct{}
+	parts    map[seriesPart]string
+	notFound string
+
+	// owners and aliases map the key (see slugKey) of each slug and alias
+	// validated so far to the path of the article that claimed it.
+	owners  map[string]string
+	aliases map[string]string
+}
+
+type seriesPart struct {
+	series string
+	part   int
 }
 
 func NewMetadataValidationHandler() *MetadataValidationHandler {
-	return &MetadataValidationHandler{slugs: make(map[string]struct{})}
+	return &MetadataValidationHandler{
+		slugs:   make(map[string]struct{}),
+		parts:   make(map[seriesPart]string),
+		owners:  make(map[string]string),
+		aliases: make(map[string]string),
+	}
 }
 
 func (this *MetadataValidationHandler) Handle(article *contracts.Article) {
+	var err error
 	if article.Metadata.Title == "" {
-		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, errBlankMetadataTitle)
-		return
+		err = errors.Join(err, errBlankMetadataTitle)
 	}
-
-	if article.Metadata.Slug == "" {
-		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, errBlankMetadataSlug)
-		return
+	switch {
+	case article.Metadata.IsNotFound() && article.Metadata.Slug != "":
+		err = errors.Join(err, errNotFoundSlug)
+	case article.Metadata.IsNotFound() && this.notFound != "":
+		err = errors.Join(err, fmt.Errorf("%w (already claimed by %s)", errRepeatedNotFoundMetadata, this.notFound))
+	case article.Metadata.IsNotFound():
+	case article.Metadata.Slug == "":
+		err = errors.Join(err, errBlankMetadataSlug)
 	}
-
-	if article.Metadata.Date.IsZero() {
-		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, errBlankMetadataDate)
+	if article.Metadata.Date.IsZero() && !article.Metadata.IsPage() {
+		err = errors.Join(err, errBlankMetadataDate)
+	}
+	if _, found := this.slugs[article.Metadata.Slug]; found && article.Metadata.Slug != "" {
+		err = errors.Join(err, errRepeatedMetadataSlug)
+	} else if claimed, found := this.aliases[slugKey(article.Metadata.Slug)]; found && article.Metadata.Slug != "" {
+		err = errors.Join(err, fmt.Errorf("%w (already an alias in %s)", errRepeatedMetadataSlug, claimed))
+	}
+	err = errors.Join(err, this.validateAliases(article))
+	switch {
+	case article.Metadata.Pinned && article.Metadata.IsPage():
+		err = errors.Join(err, errPinnedPage)
+	case article.Metadata.Weight != 0 && !article.Metadata.Pinned:
+		err = errors.Join(err, errUnpinnedWeight)
+	}
+	part := seriesPart{series: article.Metadata.Series, part: article.Metadata.SeriesPart}
+	switch {
+	case part.series != "" && article.Metadata.IsPage():
+		err = errors.Join(err, errSeriesPage)
+	case part.series != "" && part.part == 0:
+		err = errors.Join(err, errBlankMetadataSeriesPart)
+	case part.series == "" && part.part != 0:
+		err = errors.Join(err, errBlankMetadataSeries)
+	case part.series != "":
+		if claimed, found := this.parts[part]; found {
+			err = errors.Join(err, fmt.Errorf("%w: [%s] part %d (already claimed by %s)",
+				errRepeatedMetadataSeriesPart, part.series, part.part, claimed))
+		}
+	}
+	if err != nil {
+		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, err)
 		return
 	}
 
-	_, found := this.slugs[article.Metadata.Slug]
-	if found {
-		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, errRepeatedMetadataSlug)
-		return
+	if article.Metadata.Intro == "" && !article.Metadata.IsPage() {
+		article.Warn(errMissingMetadataIntro)
+	}
+	if length := utf8.RuneCountInString(article.Metadata.Title); length > maxTitleLength {
+		article.Warn(fmt.Errorf("%w: %d characters (over %d)", errLongMetadataTitle, length, maxTitleLength))
 	}
 
+	if article.Metadata.IsNotFound() {
+		article.Metadata.Slug = contracts.NotFoundSlug
+		this.notFound = article.Source.Path
+	}
 	this.slugs[article.Metadata.Slug] = struct{}{}
+	this.owners[slugKey(article.Metadata.Slug)] = article.Source.Path
+	for _, alias := range article.Metadata.Aliases {
+		this.aliases[slugKey(alias)] = article.Source.Path
+	}
+	if part.series != "" {
+		this.parts[part] = article.Source.Path
+	}
+}
+
+// validateAliases makes sure that each alias is free: neither the article's
+// own slug, nor a slug or alias claimed by another article.
+func (this *MetadataValidationHandler) validateAliases(article *contracts.Article) (err error) {
+	if len(article.Metadata.Aliases) > 0 && article.Metadata.IsNotFound() {
+		return errNotFoundAliases
+	}
+	for _, alias := range article.Metadata.Aliases {
+		if slugKey(alias) == slugKey(article.Metadata.Slug) {
+			err = errors.Join(err, fmt.Errorf("%w: [%s] (the article's own slug)", errRepeatedMetadataAlias, alias))
+		} else if claimed, found := this.owners[slugKey(alias)]; found {
+			err = errors.Join(err, fmt.Errorf("%w: [%s] (already the slug of %s)", errRepeatedMetadataAlias, alias, claimed))
+		} else if claimed, found := this.aliases[slugKey(alias)]; found {
+			err = errors.Join(err, fmt.Errorf("%w: [%s] (already an alias in %s)", errRepeatedMetadataAlias, alias, claimed))
+		}
+	}
+	return err
 }
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -59,6 +60,116 @@ func (this *MetadataValidationHandlerFixture) TestMissingDate_Err() {
 	this.So(this.article.Error, should.WrapError, errBlankMetadataDate)
 	this.So(this.article.Error.Error(), should.Contain, this.article.Source.Path)
 }
+func (this *MetadataValidationHandlerFixture) TestMissingDateOnPage_OK() {
+	this.article.Metadata.Kind = contracts.KindPage
+	this.article.Metadata.Date = time.Time{}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+}
+func (this *MetadataValidationHandlerFixture) TestSeriesOnPage_Err() {
+	this.article.Metadata.Kind = contracts.KindPage
+	this.article.Metadata.Series = "tutorial"
+	this.article.Metadata.SeriesPart = 1
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errSeriesPage)
+}
+func (this *MetadataValidationHandlerFixture) TestNotFoundPageGetsFixedSlug() {
+	this.article.Metadata.Kind = contracts.KindNotFound
+	this.article.Metadata.Slug = ""
+	this.article.Metadata.Date = time.Time{}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.article.Metadata.Slug, should.Equal, contracts.NotFoundSlug)
+}
+func (this *MetadataValidationHandlerFixture) TestNotFoundPageWithSlug_Err() {
+	this.article.Metadata.Kind = contracts.KindNotFound
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errNotFoundSlug)
+}
+func (this *MetadataValidationHandlerFixture) TestRepeatedNotFoundPage_Err() {
+	this.article.Metadata.Kind = contracts.KindNotFound
+	this.article.Metadata.Slug = ""
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+
+	this.article.Source.Path = "/the/other/path"
+	this.article.Metadata.Slug = ""
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errRepeatedNotFoundMetadata)
+	this.So(this.article.Error.Error(), should.EndWith, "(already claimed by /the/article/path)")
+}
+func (this *MetadataValidationHandlerFixture) TestUniqueAliases_OK() {
+	this.article.Metadata.Aliases = []string{"/old1", "/older1/"}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+
+	this.article.Metadata.Slug = "/slug2"
+	this.article.Metadata.Aliases = []string{"/old2"}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+}
+func (this *MetadataValidationHandlerFixture) TestAliasOfOwnSlug_Err() {
+	this.article.Metadata.Aliases = []string{"/slug1/"}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errRepeatedMetadataAlias)
+	this.So(this.article.Error.Error(), should.EndWith, "[/slug1/] (the article's own slug)")
+}
+func (this *MetadataValidationHandlerFixture) TestAliasOfAnotherSlug_Err() {
+	this.handler.Handle(this.article)
+
+	this.article.Source.Path = "/the/other/path"
+	this.article.Metadata.Slug = "/slug2"
+	this.article.Metadata.Aliases = []string{"/slug1/"}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errRepeatedMetadataAlias)
+	this.So(this.article.Error.Error(), should.EndWith, "[/slug1/] (already the slug of /the/article/path)")
+}
+func (this *MetadataValidationHandlerFixture) TestRepeatedAlias_Err() {
+	this.article.Metadata.Aliases = []string{"/old"}
+	this.handler.Handle(this.article)
+
+	this.article.Source.Path = "/the/other/path"
+	this.article.Metadata.Slug = "/slug2"
+	this.article.Metadata.Aliases = []string{"/old/"}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errRepeatedMetadataAlias)
+	this.So(this.article.Error.Error(), should.EndWith, "[/old/] (already an alias in /the/article/path)")
+}
+func (this *MetadataValidationHandlerFixture) TestSlugOfAnotherAlias_Err() {
+	this.article.Metadata.Aliases = []string{"/old"}
+	this.handler.Handle(this.article)
+
+	this.article.Source.Path = "/the/other/path"
+	this.article.Metadata.Slug = "/old/"
+	this.article.Metadata.Aliases = nil
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errRepeatedMetadataSlug)
+	this.So(this.article.Error.Error(), should.EndWith, "(already an alias in /the/article/path)")
+}
+func (this *MetadataValidationHandlerFixture) TestNotFoundPageWithAliases_Err() {
+	this.article.Metadata.Kind = contracts.KindNotFound
+	this.article.Metadata.Slug = ""
+	this.article.Metadata.Aliases = []string{"/missing/"}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errNotFoundAliases)
+}
+func (this *MetadataValidationHandlerFixture) TestPinnedWithWeight_OK() {
+	this.article.Metadata.Pinned = true
+	this.article.Metadata.Weight = 3
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+}
+func (this *MetadataValidationHandlerFixture) TestWeightWithoutPinned_Err() {
+	this.article.Metadata.Weight = 3
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errUnpinnedWeight)
+}
+func (this *MetadataValidationHandlerFixture) TestPinnedPage_Err() {
+	this.article.Metadata.Kind = contracts.KindPage
+	this.article.Metadata.Pinned = true
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errPinnedPage)
+}
 func (this *MetadataValidationHandlerFixture) TestUniqueSlugs_OK() {
 	this.assertHandleWithSlugOK("a")
 	this.assertHandleWithSlugOK("b")
@@ -80,3 +191,78 @@ func (this *MetadataValidationHandlerFixture) assertHandleWithSlugFAIL(slug stri
 	this.handler.Handle(this.article)
 	this.So(this.article.Error, should.WrapError, errRepeatedMetadataSlug)
 }
+func (this *MetadataValidationHandlerFixture) TestEveryMissingFieldReported() {
+	this.article.Metadata.Title = ""
+	this.article.Metadata.Slug = ""
+	this.article.Metadata.Date = time.Time{}
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataTitle)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataSlug)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataDate)
+}
+func (this *MetadataValidationHandlerFixture) TestRepeatedSlugReportedAlongsideMissingFields() {
+	this.assertHandleWithSlugOK("a")
+	this.article.Metadata.Title = ""
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataTitle)
+	this.So(this.article.Error, should.WrapError, errRepeatedMetadataSlug)
+}
+func (this *MetadataValidationHandlerFixture) TestSeriesWithoutPart_Err() {
+	this.article.Metadata.Series = "tutorial"
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataSeriesPart)
+}
+func (this *MetadataValidationHandlerFixture) TestPartWithoutSeries_Err() {
+	this.article.Metadata.SeriesPart = 1
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.WrapError, errBlankMetadataSeries)
+}
+func (this *MetadataValidationHandlerFixture) TestRepeatedSeriesPart_Err() {
+	this.handleSeriesPart("content/a.md", "/a", "tutorial", 1)
+	this.So(this.article.Error, should.BeNil)
+	this.handleSeriesPart("content/b.md", "/b", "tutorial", 2)
+	this.So(this.article.Error, should.BeNil)
+	this.handleSeriesPart("content/c.md", "/c", "other", 1)
+	this.So(this.article.Error, should.BeNil)
+
+	this.handleSeriesPart("content/d.md", "/d", "tutorial", 1)
+	this.So(this.article.Error, should.WrapError, errRepeatedMetadataSeriesPart)
+	this.So(this.article.Error.Error(), should.Equal,
+		"[content/d.md] repeated metadata series-part: [tutorial] part 1 (already claimed by content/a.md)")
+}
+func (this *MetadataValidationHandlerFixture) handleSeriesPart(path, slug, series string, part int) {
+	this.article.Error = nil
+	this.article.Source.Path = path
+	this.article.Metadata.Slug = slug
+	this.article.Metadata.Series = series
+	this.article.Metadata.SeriesPart = part
+	this.handler.Handle(this.article)
+}
+func (this *MetadataValidationHandlerFixture) TestNoWarningsForCompleteMetadata() {
+	this.handler.Handle(this.article)
+	this.So(this.article.Warnings, should.BeEmpty)
+}
+func (this *MetadataValidationHandlerFixture) TestMissingIntro_Warning() {
+	this.article.Metadata.Intro = ""
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.article.Warnings, should.Equal, []error{errMissingMetadataIntro})
+}
+func (this *MetadataValidationHandlerFixture) TestMissingIntroOnPage_NoWarning() {
+	this.article.Metadata.Intro = ""
+	this.article.Metadata.Kind = contracts.KindPage
+	this.handler.Handle(this.article)
+	this.So(this.article.Warnings, should.BeEmpty)
+}
+func (this *MetadataValidationHandlerFixture) TestLongTitle_Warning() {
+	this.article.Metadata.Title = strings.Repeat("á", maxTitleLength+1)
+	this.handler.Handle(this.article)
+	this.So(this.article.Error, should.BeNil)
+	this.So(len(this.article.Warnings), should.Equal, 1)
+	this.So(this.article.Warnings[0], should.WrapError, errLongMetadataTitle)
+}
+func (this *MetadataValidationHandlerFixture) TestTitleAtLimit_NoWarning() {
+	this.article.Metadata.Title = strings.Repeat("á", maxTitleLength)
+	this.handler.Handle(this.article)
+	this.So(this.article.Warnings, should.BeEmpty)
+}
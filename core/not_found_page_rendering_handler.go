@@ -0,0 +1,105 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// NotFoundPageRenderingHandler writes /404.html (where static hosts look
+// for a not-found page) from the article of kind not-found, or, if there
+// isn't one, from the 404.tmpl template alone (if there is one). Because
+// that page is served at any depth, its relative links are made absolute.
+type NotFoundPageRenderingHandler struct {
+	disk     RenderingFileSystem
+	renderer contracts.Renderer
+	basePath string
+	output   string
+	rendered bool
+}
+
+func NewNotFoundPageRenderingHandler(
+	disk RenderingFileSystem,
+	renderer contracts.Renderer,
+	basePath string,
+	output string,
+) *NotFoundPageRenderingHandler {
+	return &NotFoundPageRenderingHandler{
+		disk:     disk,
+		renderer: renderer,
+		basePath: basePath,
+		output:   output,
+	}
+}
+
+func (this *NotFoundPageRenderingHandler) Handle(article *contracts.Article) {
+	if !article.Metadata.IsNotFound() {
+		return
+	}
+	this.rendered = true
+	err := this.render(contracts.RenderedNotFoundPage{RenderedArticle: contracts.RenderedArticle{
+		Slug:    article.Metadata.Slug,
+		Title:   article.Metadata.Title,
+		Intro:   article.Metadata.Intro,
+		Date:    article.Metadata.Date,
+		Topics:  article.Metadata.Topics,
+		Content: article.Content.Converted,
+	}})
+	if err != nil {
+		article.Error = fmt.Errorf("[%s] %w", article.Source.Path, err)
+	}
+}
+
+func (this *NotFoundPageRenderingHandler) Finalize() error {
+	if this.rendered {
+		return nil
+	}
+	templates, ok := this.renderer.(contracts.TemplateSet)
+	if !ok || !templates.HasTemplate(contracts.NotFoundTemplateName) {
+		return nil
+	}
+	return this.render(contracts.RenderedNotFoundPage{
+		RenderedArticle: contracts.RenderedArticle{Slug: contracts.NotFoundSlug},
+	})
+}
+
+func (this *NotFoundPageRenderingHandler) render(page contracts.RenderedNotFoundPage) error {
+	rendered, err := this.renderer.Render(page)
+	if err != nil {
+		return err
+	}
+
+	err = this.disk.MkdirAll(this.output, 0755)
+	if err != nil {
+		return err
+	}
+
+	rendered = this.absolute(rendered)
+	return this.disk.WriteFile(filepath.Join(this.output, strings.TrimPrefix(contracts.NotFoundSlug, "/")), []byte(rendered), 0644)
+}
+
+// absolute rewrites relative href/src links as absolute ones (under the
+// base path), resolving them against the site root, where 404.html lives.
+func (this *NotFoundPageRenderingHandler) absolute(rendered string) string {
+	return relativeLinkAttribute.ReplaceAllStringFunc(rendered, func(match string) string {
+		groups := relativeLinkAttribute.FindStringSubmatch(match)
+		parsed, err := url.Parse(groups[3])
+		if err != nil || parsed.Scheme != "" || parsed.Host != "" || parsed.Opaque != "" ||
+			parsed.Path == "" || strings.HasPrefix(parsed.Path, "/") {
+			return match
+		}
+		resolved := path.Join("/", this.basePath, parsed.Path)
+		if strings.HasSuffix(parsed.Path, "/") && resolved != "/" {
+			resolved += "/"
+		}
+		parsed.Path = resolved
+		return groups[1] + groups[2] + parsed.String() + groups[2]
+	})
+}
+
+var relativeLinkAttribute = regexp.MustCompile(`(\s(?:href|src)=)(["'])([^"']*)["']`)
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestNotFoundPageRenderingHandlerFixture(t *testing.T) {
+	suite.Run(&NotFoundPageRenderingHandlerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type NotFoundPageRenderingHandlerFixture struct {
+	*suite.T
+
+	handler  *NotFoundPageRenderingHandler
+	renderer *FakeTemplateSetRenderer
+	disk     *FakeFileSystem
+	article  *contracts.Article
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) Setup() {
+	this.renderer = &FakeTemplateSetRenderer{FakeRenderer: NewFakeRenderer()}
+	this.disk = NewFakeFileSystem()
+	this.handler = NewNotFoundPageRenderingHandler(this.disk, this.renderer, "", "output/folder")
+	this.article = &contracts.Article{
+		Source: contracts.ArticleSource{Path: "content/404.md"},
+		Metadata: contracts.ArticleMetadata{
+			Kind:  contracts.KindNotFound,
+			Slug:  contracts.NotFoundSlug,
+			Title: "Not Found",
+		},
+		Content: contracts.ArticleContent{Converted: "CONTENT"},
+	}
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestOtherArticlesIgnored() {
+	this.handler.Handle(&contracts.Article{Metadata: contracts.ArticleMetadata{Kind: contracts.KindPage, Slug: "/about/"}})
+
+	this.So(this.handler.Finalize(), should.BeNil)
+	this.So(this.renderer.all, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestNotFoundArticleWrittenToRoot() {
+	this.renderer.result = "RENDERED"
+	this.renderer.templates = []string{contracts.NotFoundTemplateName}
+
+	this.handler.Handle(this.article)
+
+	this.So(this.article.Error, should.BeNil)
+	this.So(this.renderer.rendered, should.Equal, contracts.RenderedNotFoundPage{RenderedArticle: contracts.RenderedArticle{
+		Slug:    contracts.NotFoundSlug,
+		Title:   "Not Found",
+		Content: "CONTENT",
+	}})
+	this.So(this.disk.Content("output/folder/404.html"), should.Equal, "RENDERED")
+	this.So(this.handler.Finalize(), should.BeNil)
+	this.So(len(this.renderer.all), should.Equal, 1)
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestTemplateAloneRenderedWithoutNotFoundArticle() {
+	this.renderer.result = "RENDERED"
+	this.renderer.templates = []string{contracts.NotFoundTemplateName}
+
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	this.So(this.renderer.rendered, should.Equal, contracts.RenderedNotFoundPage{
+		RenderedArticle: contracts.RenderedArticle{Slug: contracts.NotFoundSlug},
+	})
+	this.So(this.disk.Content("output/folder/404.html"), should.Equal, "RENDERED")
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestNothingRenderedWithoutArticleOrTemplate() {
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	this.So(this.renderer.all, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestRelativeLinksMadeAbsolute() {
+	this.handler = NewNotFoundPageRenderingHandler(this.disk, this.renderer, "/base", "output/folder")
+	this.renderer.result = `<link href="style.css"><img src='img/logo.png'>` +
+		`<a href="about/">about</a> <a href="/already/">absolute</a> <a href="#top">top</a> ` +
+		`<a href="https://example.com/x">elsewhere</a> <a href="search?q=1&amp;p=2">search</a>`
+
+	this.handler.Handle(this.article)
+
+	this.So(this.disk.Content("output/folder/404.html"), should.Equal,
+		`<link href="/base/style.css"><img src='/base/img/logo.png'>`+
+			`<a href="/base/about/">about</a> <a href="/already/">absolute</a> <a href="#top">top</a> `+
+			`<a href="https://example.com/x">elsewhere</a> <a href="/base/search?q=1&amp;p=2">search</a>`)
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestRenderErrorReportedAgainstArticle() {
+	renderErr := errors.New("boink")
+	this.renderer.err = renderErr
+
+	this.handler.Handle(this.article)
+
+	this.So(this.article.Error, should.WrapError, renderErr)
+	this.So(this.article.Error.Error(), should.Equal, "[content/404.md] boink")
+	this.So(this.disk.Paths(), should.BeEmpty)
+}
+
+func (this *NotFoundPageRenderingHandlerFixture) TestWriteFileErrorReturned() {
+	writeFileErr := errors.New("boink")
+	this.disk.ErrWriteFile["output/folder/404.html"] = writeFileErr
+	this.renderer.templates = []string{contracts.NotFoundTemplateName}
+
+	this.So(this.handler.Finalize(), should.WrapError, writeFileErr)
+}
+
+///////////////////////////////////////////////////////////////////
+
+type FakeTemplateSetRenderer struct {
+	*FakeRenderer
+	templates []string
+}
+
+func (this *FakeTemplateSetRenderer) HasTemplate(name string) bool {
+	for _, template := range this.templates {
+		if template == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package core
+
+import "github.com/mdw-tools/hugoinho/contracts"
+
+// ObservedHandler decorates a contracts.Handler, reporting the time spent
+// in Handle (and Finalize, when the inner handler has one) to an observer.
+type ObservedHandler struct {
+	stage    contracts.Stage
+	inner    contracts.Handler
+	clock    contracts.Clock
+	observer contracts.Observer
+}
+
+func NewObservedHandler(
+	stage contracts.Stage,
+	inner contracts.Handler,
+	clock contracts.Clock,
+	observer contracts.Observer,
+) *ObservedHandler {
+	return &ObservedHandler{
+		stage:    stage,
+		inner:    inner,
+		clock:    clock,
+		observer: observer,
+	}
+}
+
+func (this *ObservedHandler) Handle(article *contracts.Article) {
+	started := this.clock()
+	this.inner.Handle(article)
+	this.observer.Observe(this.stage, article, started, this.clock())
+}
+
+func (this *ObservedHandler) Finalize() error {
+	finalizer, ok := this.inner.(contracts.Finalizer)
+	if !ok {
+		return nil
+	}
+	started := this.clock()
+	err := finalizer.Finalize()
+	this.observer.Observe(this.stage, nil, started, this.clock())
+	return err
+}
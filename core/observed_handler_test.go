@@ -0,0 +1,95 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestObservedHandlerFixture(t *testing.T) {
+	suite.Run(&ObservedHandlerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type ObservedHandlerFixture struct {
+	*suite.T
+
+	now      time.Time
+	stage    contracts.Stage
+	observer *FakeObserver
+}
+
+func (this *ObservedHandlerFixture) Setup() {
+	this.now = Date(2022, 1, 1)
+	this.stage = contracts.Stage{Index: 1, Name: "Stage"}
+	this.observer = NewFakeObserver()
+}
+
+func (this *ObservedHandlerFixture) clock() time.Time {
+	defer func() { this.now = this.now.Add(time.Millisecond) }()
+	return this.now
+}
+
+func (this *ObservedHandlerFixture) TestHandleObserved() {
+	inner := NewFakeHandler()
+	handler := NewObservedHandler(this.stage, inner, this.clock, this.observer)
+	article := &contracts.Article{Content: contracts.ArticleContent{Original: "A"}}
+
+	handler.Handle(article)
+
+	this.So(inner.calls, should.Equal, 1)
+	this.So(article.Content.Converted, should.Equal, "A1")
+	this.So(this.observer.observations, should.Equal, []Observation{
+		{Stage: this.stage, Article: article, Elapsed: time.Millisecond},
+	})
+}
+
+func (this *ObservedHandlerFixture) TestFinalizeObservedWithoutArticle() {
+	inner := NewFakeFinalizingHandler()
+	inner.err = errors.New("boink")
+	handler := NewObservedHandler(this.stage, inner, this.clock, this.observer)
+
+	err := handler.Finalize()
+
+	this.So(err, should.WrapError, inner.err)
+	this.So(inner.called, should.Equal, 1)
+	this.So(this.observer.observations, should.Equal, []Observation{
+		{Stage: this.stage, Article: nil, Elapsed: time.Millisecond},
+	})
+}
+
+func (this *ObservedHandlerFixture) TestFinalizeWithoutInnerFinalizer_NotObserved() {
+	handler := NewObservedHandler(this.stage, NewFakeHandler(), this.clock, this.observer)
+
+	err := handler.Finalize()
+
+	this.So(err, should.BeNil)
+	this.So(this.observer.observations, should.BeEmpty)
+}
+
+///////////////////////////////////////////////////////////////
+
+type FakeObserver struct {
+	observations []Observation
+}
+
+type Observation struct {
+	Stage   contracts.Stage
+	Article *contracts.Article
+	Elapsed time.Duration
+}
+
+func NewFakeObserver() *FakeObserver {
+	return &FakeObserver{}
+}
+
+func (this *FakeObserver) Observe(stage contracts.Stage, article *contracts.Article, started, finished time.Time) {
+	this.observations = append(this.observations, Observation{
+		Stage:   stage,
+		Article: article,
+		Elapsed: finished.Sub(started),
+	})
+}
@@ -16,20 +16,20 @@ func TestPathLoaderFixture(t *testing.T) {
 type PathLoaderFixture struct {
 	*suite.T
 	loader *PathLoader
-	files  *InMemoryFileSystem
+	files  *FakeFileSystem
 	output chan contracts.Article
 }
 
 func (this *PathLoaderFixture) Setup() {
-	this.files = NewInMemoryFileSystem()
+	this.files = NewFakeFileSystem()
 	this.output = make(chan contracts.Article, 10)
 	this.loader = NewPathLoader(this.files, "/content", this.output)
 
-	_ = this.files.WriteFile("/article1.md", []byte("outside of content root"), 0644)
-	_ = this.files.WriteFile("/content/article1.md", []byte("article1"), 0644)
-	_ = this.files.WriteFile("/content/article2.txt", []byte("not an article"), 0644)
+	this.files.Write("/article1.md", "outside of content root")
+	this.files.Write("/content/article1.md", "article1")
+	this.files.Write("/content/article2.txt", "not an article")
 	_ = this.files.MkdirAll("/content/folder", 0577)
-	_ = this.files.WriteFile("/content/folder/article3.md", []byte("article3"), 0644)
+	this.files.Write("/content/folder/article3.md", "article3")
 }
 
 func (this *PathLoaderFixture) Test() {
@@ -1,12 +1,19 @@
 package core
 
-import "github.com/mdw-tools/hugoinho/contracts"
+import (
+	"reflect"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
 
 type Pipeline struct {
 	clock    contracts.Clock
 	config   contracts.Config
 	disk     contracts.FileSystem
 	renderer contracts.Renderer
+	observer contracts.Observer
+	stages   int
+	warners  []contracts.Warner
 }
 
 func NewPipeline(
@@ -14,40 +21,74 @@ func NewPipeline(
 	config contracts.Config,
 	disk contracts.FileSystem,
 	renderer contracts.Renderer,
+	observer contracts.Observer,
 ) *Pipeline {
 	return &Pipeline{
 		clock:    clock,
 		config:   config,
 		disk:     disk,
 		renderer: renderer,
+		observer: observer,
 	}
 }
+
+// Run prepares every article, then (once all of them are prepared) renders
+// the pages, each of which may consult the site index of published articles.
 func (this *Pipeline) Run() (out chan contracts.Article) {
-	out = this.goLoad()
-	out = this.goListen(out, NewFileReadingHandler(this.disk))
-	out = this.goListen(out, NewMetadataParsingHandler())
-	out = this.goListen(out, NewMetadataValidationHandler())
-	out = this.goListen(out, NewDraftFilteringHandler(!this.config.BuildDrafts))
-	out = this.goListen(out, NewFutureFilteringHandler(this.clock(), !this.config.BuildFuture))
-	out = this.goListen(out, NewContentConversionHandler(NewGoldmarkMarkdownConverter()))
-	out = this.goListen(out, NewArticleRenderingHandler(this.disk, this.renderer, this.config.TargetRoot))
-	out = this.goListen(out, NewTopicPageRenderingHandler(this.disk, this.renderer, this.config.TargetRoot))
-	out = this.goListen(out, NewArchivesRenderingHandler(
-		filterAll,
-		sortByDateDescending,
+	site := NewSiteIndex()
+	out = this.goPrepare()
+	out = this.goCollect(out, site.Add)
+	out = this.goListen(out, NewArticleRenderingHandler(
+		this.disk,
 		this.renderer,
+		site,
+		this.config.TopicNeighbors,
+		this.config.RelatedCount,
+		this.config.TargetRoot,
+	))
+	out = this.goListen(out, NewNotFoundPageRenderingHandler(
 		this.disk,
+		this.renderer,
+		this.config.BasePath,
 		this.config.TargetRoot,
 	))
+	out = this.goListen(out, NewRedirectRenderingHandler(this.disk, this.config.BasePath, this.config.TargetRoot))
+	topics := TopicSettings{Minimum: this.config.TopicMinimum, Order: this.config.TopicOrder}
+	out = this.goListen(out, NewTopicPageRenderingHandler(this.disk, this.renderer, site, topics, this.config.TargetRoot))
+	out = this.goListen(out, NewSeriesPageRenderingHandler(this.disk, this.renderer, site, this.config.TargetRoot))
+	out = this.goListen(out, NewArchivesRenderingHandler(site, this.renderer, this.disk, this.config.TargetRoot))
 	out = this.goListen(out, NewHomepageRenderingHandler(
-		filterAll,
-		sortByDateDescending,
+		site,
 		this.renderer,
 		this.disk,
+		HomepageCounts{Recent: this.config.HomeRecent, Topics: this.config.HomeTopics},
+		topics,
 		this.config.TargetRoot,
 	))
 	return out
 }
+
+// Check runs only the stages that read, validate and convert content:
+// nothing is rendered or written, so neither renderer nor target is used.
+func (this *Pipeline) Check() (out chan contracts.Article) {
+	return this.goPrepare()
+}
+
+// goPrepare reads, validates and converts content. Run follows it with a
+// barrier that collects the site index, then the stages that render pages.
+func (this *Pipeline) goPrepare() (out chan contracts.Article) {
+	out = this.goLoad()
+	out = this.goListen(out, NewFileReadingHandler(this.disk))
+	out = this.goListen(out, NewMetadataParsingHandler())
+	out = this.goListen(out, NewMetadataValidationHandler())
+	out = this.goListen(out, NewDraftFilteringHandler(!this.config.BuildDrafts))
+	out = this.goListen(out, NewFutureFilteringHandler(this.clock(), !this.config.BuildFuture))
+	out = this.goListen(out, NewContentConversionHandler(NewGoldmarkMarkdownConverter()))
+	index := NewArticleIndex()
+	out = this.goCollect(out, index.Add)
+	out = this.goListen(out, NewLinkRewritingHandler(index))
+	return out
+}
 func (this *Pipeline) goLoad() (out chan contracts.Article) {
 	out = make(chan contracts.Article)
 	loader := NewPathLoader(this.disk, this.config.ContentRoot, out)
@@ -60,12 +101,53 @@ func (this *Pipeline) goLoad() (out chan contracts.Article) {
 	}()
 	return out
 }
+
+// goCollect is a barrier: it holds back every article until all of them
+// have been collected (e.g. into an index), so that later stages can
+// consult what was collected.
+func (this *Pipeline) goCollect(in chan contracts.Article, collect func(contracts.Article)) (out chan contracts.Article) {
+	out = make(chan contracts.Article)
+	go func() {
+		defer close(out)
+		var articles []contracts.Article
+		for article := range in {
+			collect(article)
+			articles = append(articles, article)
+		}
+		for _, article := range articles {
+			out <- article
+		}
+	}()
+	return out
+}
 func (this *Pipeline) goListen(in chan contracts.Article, handler contracts.Handler) (out chan contracts.Article) {
 	out = make(chan contracts.Article)
+	if warner, ok := handler.(contracts.Warner); ok {
+		this.warners = append(this.warners, warner)
+	}
+	if this.observer != nil {
+		handler = NewObservedHandler(this.nextStage(handler), handler, this.clock, this.observer)
+	}
 	go Listen(in, out, handler)
 	return out
 }
-func filterAll(*contracts.Article) bool { return true }
+
+// Warnings gathers the site-wide warnings from each handler that has any.
+// Call it only after the channel returned by Run has been drained.
+func (this *Pipeline) Warnings() (warnings []error) {
+	for _, warner := range this.warners {
+		warnings = append(warnings, warner.Warnings()...)
+	}
+	return warnings
+}
+func (this *Pipeline) nextStage(handler contracts.Handler) contracts.Stage {
+	this.stages++
+	return contracts.Stage{
+		Index: this.stages,
+		Name:  reflect.Indirect(reflect.ValueOf(handler)).Type().Name(),
+	}
+}
+
 func sortByDateDescending(i, j contracts.RenderedArticleSummary) int {
 	return -i.Date.Compare(j.Date)
 }
@@ -1,13 +1,24 @@
 package core
 
-import "github.com/mdw-tools/hugoinho/contracts"
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
 
 type PipelineRunner struct {
 	version string
 	args    []string
 	fs      contracts.FileSystem
 	now     contracts.Clock
+	output  io.Writer
 	log     contracts.Logger
+	trace   *TraceRecorder
 }
 
 func NewPipelineRunner(
@@ -15,47 +26,275 @@ func NewPipelineRunner(
 	args []string,
 	fs contracts.FileSystem,
 	now contracts.Clock,
-	log contracts.Logger,
+	output io.Writer,
 ) *PipelineRunner {
 	return &PipelineRunner{
 		version: version,
 		args:    args,
 		fs:      fs,
 		now:     now,
-		log:     log,
+		output:  output,
+		log:     NewLogger(output, LogFormatText, slog.LevelInfo),
 	}
 }
 
-func (this *PipelineRunner) Run() (errors int) {
+// Run builds the site, returning the exit code: 0 for a clean build and 1
+// when anything failed (with -fail-on-warnings, warnings included).
+func (this *PipelineRunner) Run() int {
 	start := this.now()
 
 	config, err := NewCLIParser(this.version, this.args).Parse()
 	if err != nil {
-		this.log.Println(err)
+		this.log.Error("invalid configuration", "error", err)
 		return 1
 	}
+	this.log = NewLogger(this.output, config.LogFormat, config.LogLevel)
 
 	loader := NewTemplateLoader(this.fs, config.TemplateDir)
 	templates, err := loader.Load()
 	if err != nil {
-		this.log.Println(err)
+		this.log.Error("failed to load templates", "error", err)
 		return 1
 	}
 
 	templateRenderer := NewTemplateRenderer(templates)
 	err = templateRenderer.Validate()
 	if err != nil {
-		this.log.Println(err)
+		this.log.Error("invalid templates", "error", err)
 		return 1
 	}
 
+	if config.TracePath != "" {
+		this.trace = NewTraceRecorder(start)
+	}
+
 	var renderer contracts.Renderer = templateRenderer
 	if len(config.BasePath) > 0 {
 		renderer = NewBasePathRenderer(templateRenderer, config.BasePath)
 	}
-	pipeline := NewPipeline(this.now, config, this.fs, renderer)
+	if config.DryRun {
+		return this.dryRun(start, config, renderer)
+	}
+	if config.BuildInPlace {
+		return this.build(start, config, config.TargetRoot, renderer, nil)
+	}
+
+	publisher := NewPublisher(this.fs, config.TargetRoot, config.AllowedLinks)
+	staging, err := publisher.Prepare()
+	if err != nil {
+		this.log.Error("failed to prepare staging directory", "error", err)
+		return 1
+	}
+	return this.build(start, config, staging, renderer, publisher)
+}
+
+func (this *PipelineRunner) build(
+	start time.Time,
+	config contracts.Config,
+	output string,
+	renderer contracts.Renderer,
+	publisher *Publisher,
+) int {
+	tracker := NewTrackingFileSystem(this.fs)
+	reporter := this.process(start, config, output, tracker, renderer)
+	this.checkLinks(config, output, tracker, reporter)
+	if failures(config, reporter) == 0 && (config.Prune || config.PruneDryRun) {
+		this.prune(config, tracker.Produced(output), reporter)
+	}
+	if publisher != nil {
+		this.publish(config, publisher, reporter)
+	}
+	return this.finish(config, reporter)
+}
+
+// dryRun renders the site without writing anything, then reports how
+// the result differs from what is currently in the target directory.
+func (this *PipelineRunner) dryRun(start time.Time, config contracts.Config, renderer contracts.Renderer) int {
+	recorder := NewRecordingFileSystem(this.fs)
+	tracker := NewTrackingFileSystem(recorder)
+	reporter := this.process(start, config, config.TargetRoot, tracker, renderer)
+	this.checkLinks(config, config.TargetRoot, tracker, reporter)
+
+	changes, err := recorder.Compare()
+	if err != nil {
+		reporter.accountFor(contracts.Article{Error: err})
+	}
+	removed, err := NewPruner(this.fs, config.TargetRoot, tracker.Produced(config.TargetRoot), config.AllowedLinks).Stale()
+	if err != nil {
+		reporter.accountFor(contracts.Article{Error: err})
+	}
+	for _, path := range changes.New {
+		this.log.Info("dry run: new file", "path", path)
+	}
+	for _, path := range changes.Modified {
+		this.log.Info("dry run: modified file", "path", path)
+	}
+	for _, path := range removed {
+		this.log.Info("dry run: removed file", "path", path)
+	}
+	this.log.Info("dry run summary",
+		"new", len(changes.New),
+		"modified", len(changes.Modified),
+		"unchanged", len(changes.Unchanged),
+		"removed", len(removed),
+	)
+
+	return this.finish(config, reporter)
+}
+
+func (this *PipelineRunner) finish(config contracts.Config, reporter *Reporter) int {
+	return finish(this.log, this.fs, config, reporter, this.now(), this.trace)
+}
+
+// finish renders the final report, writes the -report (and, when
+// recording, -trace) files and turns the outcome into an exit code.
+func finish(log contracts.Logger, disk contracts.WriteFile, config contracts.Config, reporter *Reporter, finished time.Time, trace *TraceRecorder) int {
+	reporter.RenderFinalReport(finished)
+	errors := failures(config, reporter)
+	if config.ReportPath != "" {
+		err := writeReport(disk, config, reporter.Report(finished))
+		if err != nil {
+			log.Error("failed to write build report", "path", config.ReportPath, "error", err)
+			errors++
+		}
+	}
+	if trace != nil {
+		err := writeTrace(disk, config, trace)
+		if err != nil {
+			log.Error("failed to write trace", "path", config.TracePath, "error", err)
+			errors++
+		}
+	}
+	// The count itself is in the final report: as an exit code it would be
+	// truncated (modulo 256), so 256 problems would pass.
+	if errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+func writeTrace(disk contracts.WriteFile, config contracts.Config, trace *TraceRecorder) error {
+	content, err := trace.JSON()
+	if err != nil {
+		return err
+	}
+	return disk.WriteFile(config.TracePath, content, 0644)
+}
+
+func writeReport(disk contracts.WriteFile, config contracts.Config, report BuildReport) error {
+	var content []byte
+	var err error
+	switch config.ReportFormat {
+	case ReportFormatJUnit:
+		content, err = report.JUnit()
+	default:
+		content, err = report.JSON()
+	}
+	if err != nil {
+		return err
+	}
+	return disk.WriteFile(config.ReportPath, content, 0644)
+}
+
+func (this *PipelineRunner) process(
+	start time.Time,
+	config contracts.Config,
+	output string,
+	disk contracts.FileSystem,
+	renderer contracts.Renderer,
+) *Reporter {
+	config.TargetRoot = output
 	reporter := NewReporter(start, this.log)
+	var observer contracts.Observer = reporter
+	if this.trace != nil {
+		observer = Observers{reporter, this.trace}
+	}
+	pipeline := NewPipeline(this.now, config, disk, renderer, observer)
 	reporter.ProcessStream(pipeline.Run())
-	reporter.RenderFinalReport(this.now())
+	reporter.ProcessWarnings(pipeline.Warnings())
+	return reporter
+}
+
+// failures counts the problems that should fail the build: errors, and
+// (with -fail-on-warnings) warnings.
+func failures(config contracts.Config, reporter *Reporter) int {
+	if config.FailOnWarnings {
+		return reporter.Errors() + reporter.Warnings()
+	}
 	return reporter.Errors()
 }
+
+// checkLinks reports each broken internal link in the rendered output as
+// an error against the article on whose page it was found (or, when only
+// the anchor is missing, as a warning).
+func (this *PipelineRunner) checkLinks(config contracts.Config, output string, tracker *TrackingFileSystem, reporter *Reporter) {
+	checker := NewLinkChecker(tracker, output, config.BasePath, tracker.Produced(output), config.AllowedLinks)
+	broken, err := checker.Check()
+	if err != nil {
+		reporter.accountFor(contracts.Article{Error: err})
+	}
+	articles := make(map[string]ArticleReport)
+	for _, article := range reporter.articles {
+		if article.Outcome == OutcomePublished {
+			articles[pageFile(article.Slug)] = article
+		}
+	}
+	for _, link := range broken {
+		article := articles[link.Page]
+		var err error = link
+		if article.Path != "" {
+			err = fmt.Errorf("[%s] %w", article.Path, link)
+		}
+		if link.Reason == reasonNoSuchAnchor {
+			reporter.warn(article.Path, err)
+		} else {
+			reporter.fail(article.Path, err)
+		}
+	}
+}
+
+// pageFile is the file (relative to the target) to which the article
+// with the given slug is rendered.
+func pageFile(slug string) string {
+	if slug == contracts.NotFoundSlug {
+		return strings.TrimPrefix(slug, "/")
+	}
+	return path.Join(strings.Trim(slug, "/"), "index.html")
+}
+
+// prune compares the target directory with what the build produced. In a
+// staged build the swap itself discards stale files, so only in-place
+// builds (see validateConfig) need to remove anything.
+func (this *PipelineRunner) prune(config contracts.Config, produced map[string]struct{}, reporter *Reporter) {
+	pruner := NewPruner(this.fs, config.TargetRoot, produced, config.AllowedLinks)
+	if config.PruneDryRun {
+		stale, err := pruner.Stale()
+		for _, path := range stale {
+			this.log.Info("stale file (would prune)", "path", path)
+		}
+		if err != nil {
+			reporter.accountFor(contracts.Article{Error: err})
+		}
+		return
+	}
+	removed, err := pruner.Prune()
+	for _, path := range removed {
+		this.log.Info("pruned stale file", "path", path)
+	}
+	if err != nil {
+		reporter.accountFor(contracts.Article{Error: err})
+	}
+}
+
+func (this *PipelineRunner) publish(config contracts.Config, publisher *Publisher, reporter *Reporter) {
+	if failures(config, reporter) > 0 {
+		this.log.Warn("build failed; leaving target directory untouched")
+		if err := publisher.Abandon(); err != nil {
+			reporter.accountFor(contracts.Article{Error: err})
+		}
+		return
+	}
+	if err := publisher.Publish(); err != nil {
+		reporter.accountFor(contracts.Article{Error: err})
+	}
+}
@@ -4,9 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -23,16 +24,17 @@ type PipelineRunnerFixture struct {
 	*suite.T
 
 	log      *bytes.Buffer
+	clock    sync.Mutex
 	started  time.Time
 	finished time.Time
 	args     []string
-	disk     *InMemoryFileSystem
+	disk     *FakeFileSystem
 	runner   *PipelineRunner
 }
 
 func (this *PipelineRunnerFixture) Setup() {
 	this.log = new(bytes.Buffer)
-	this.disk = NewInMemoryFileSystem()
+	this.disk = NewFakeFileSystem()
 
 	this.file("content/a.md", ContentA)
 	this.file("content/b.md", ContentB)
@@ -47,10 +49,12 @@ func (this *PipelineRunnerFixture) Setup() {
 func (this *PipelineRunnerFixture) buildRunner() *PipelineRunner {
 	this.started = Date(2022, 1, 1)
 	this.finished = this.started.Add(time.Millisecond)
-	this.runner = NewPipelineRunner("version", this.args, this.disk, this.Now, log.New(this.log, "", 0))
+	this.runner = NewPipelineRunner("version", this.args, this.disk, this.Now, this.log)
 	return this.runner
 }
 func (this *PipelineRunnerFixture) Now() time.Time {
+	this.clock.Lock()
+	defer this.clock.Unlock()
 	defer func() {
 		this.started = this.finished
 	}()
@@ -61,7 +65,7 @@ func (this *PipelineRunnerFixture) arg(values ...string) {
 	this.args = append(this.args, values...)
 }
 func (this *PipelineRunnerFixture) file(path, content string) {
-	_ = this.disk.WriteFile(path, []byte(content), 0644)
+	this.disk.Write(path, content)
 }
 func (this *PipelineRunnerFixture) ls(root string) {
 	files := this.disk.Walk(root)
@@ -70,15 +74,14 @@ func (this *PipelineRunnerFixture) ls(root string) {
 	}
 }
 func (this *PipelineRunnerFixture) assertFolder(path string) {
-	dir := this.disk.Files[path]
-	this.So(dir, better.NOT.BeNil)
+	dir, err := this.disk.Stat(path)
+	this.So(err, better.BeNil)
 	this.So(dir.IsDir(), should.BeTrue)
 }
 func (this *PipelineRunnerFixture) assertFile(path, expectedContent string) {
 	this.Println("Path:", path)
-	file := this.disk.Files[path]
-	this.So(file, better.NOT.BeNil)
-	actual := strings.ReplaceAll(strings.TrimSpace(file.Content()), "\n", `\n`)
+	this.So(this.disk.Paths(), better.Contain, path)
+	actual := strings.ReplaceAll(strings.TrimSpace(this.disk.Content(path)), "\n", `\n`)
 	expected := strings.ReplaceAll(strings.TrimSpace(expectedContent), "\n", `\n`)
 	this.So(actual, should.Equal, expected)
 }
@@ -125,9 +128,477 @@ func (this *PipelineRunnerFixture) TestValidConfigAndTemplates_PipelineRuns() {
 	this.assertRenderedDiskState()
 }
 
+func (this *PipelineRunnerFixture) TestHandPlacedFilesCarriedIntoStagedBuild() {
+	this.arg("-allow-links", "/img/")
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "![logo](/img/logo.png)", 1))
+	this.file("rendered/img/logo.png", "LOGO")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/img/logo.png", "LOGO")
+	this.assertFile("rendered.prev/img/logo.png", "LOGO")
+}
+
+func (this *PipelineRunnerFixture) TestPreviousBuildKeptForRollback() {
+	_ = this.disk.MkdirAll("rendered/stale", 0755)
+	this.file("rendered/stale/index.html", "STALE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/stale/index.html")
+	this.assertFile("rendered.prev/stale/index.html", "STALE")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.staging")
+}
+
+func (this *PipelineRunnerFixture) TestBuildErrorsLeaveTargetUntouched() {
+	_ = this.disk.MkdirAll("rendered", 0755)
+	this.file("rendered/index.html", "LIVE")
+	this.file("content/d.md", "not an article")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.assertFile("rendered/index.html", "LIVE")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/article-a")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.staging")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.prev")
+}
+
+func (this *PipelineRunnerFixture) TestInPlaceBuildWritesDirectlyToTarget() {
+	this.arg("-in-place")
+	_ = this.disk.MkdirAll("rendered/stale", 0755)
+	this.file("rendered/stale/index.html", "STALE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/stale/index.html", "STALE")
+	this.assertFile("rendered/article-a/index.html", RenderedArticleA)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.staging")
+}
+
+func (this *PipelineRunnerFixture) TestPruneKeepsHandPlacedFiles() {
+	this.arg("-in-place", "-prune", "-allow-links", "/img/")
+	this.file("rendered/img/logo.png", "LOGO")
+	this.file("rendered/stale/index.html", "STALE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/img/logo.png", "LOGO")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/stale")
+}
+
+func (this *PipelineRunnerFixture) TestPruneWithoutInPlaceRejected() {
+	this.arg("-prune")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.log.String(), should.Contain, "-prune requires -in-place")
+	this.assertOriginalDiskState()
+}
+
+func (this *PipelineRunnerFixture) TestInPlaceBuildPrunesStaleFiles() {
+	this.arg("-in-place", "-prune")
+	_ = this.disk.MkdirAll("rendered/stale", 0755)
+	this.file("rendered/stale/index.html", "STALE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/stale")
+	this.So(this.log.String(), should.Contain, `msg="pruned stale file" path=rendered/stale`+"\n")
+	this.assertRenderedDiskState()
+}
+
+func (this *PipelineRunnerFixture) TestPruneDryRunListsStaleFilesWithoutRemovingThem() {
+	this.arg("-in-place", "-prune-dry-run")
+	_ = this.disk.MkdirAll("rendered/stale", 0755)
+	this.file("rendered/stale/index.html", "STALE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/stale/index.html", "STALE")
+	this.So(this.log.String(), should.Contain, `msg="stale file (would prune)" path=rendered/stale`+"\n")
+}
+
+func (this *PipelineRunnerFixture) TestPruneDryRunComparesStagedBuildWithLiveTarget() {
+	this.arg("-prune-dry-run")
+	_ = this.disk.MkdirAll("rendered/stale", 0755)
+	this.file("rendered/stale/index.html", "STALE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.Contain, `msg="stale file (would prune)" path=rendered/stale`+"\n")
+	this.So(strings.Count(this.log.String(), "would prune"), should.Equal, 1)
+}
+
+func (this *PipelineRunnerFixture) TestDryRunReportsPlannedWritesWithoutWriting() {
+	this.So(this.buildRunner().Run(), should.Equal, 0)
+	_ = this.disk.RemoveAll("rendered.prev")
+	_ = this.disk.RemoveAll("rendered/article-b")
+	_ = this.disk.MkdirAll("rendered/stale", 0755)
+	this.file("rendered/index.html", "OUTDATED")
+	this.file("rendered/stale/index.html", "STALE")
+	before := len(this.disk.Files())
+	this.log.Reset()
+	this.arg("-dry-run")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(len(this.disk.Files()), should.Equal, before)
+	this.assertFile("rendered/index.html", "OUTDATED")
+	output := this.log.String()
+	this.So(output, should.Contain, `msg="dry run: new file" path=rendered/article-b/index.html`+"\n")
+	this.So(output, should.Contain, `msg="dry run: modified file" path=rendered/index.html`+"\n")
+	this.So(output, should.Contain, `msg="dry run: removed file" path=rendered/stale`+"\n")
+	this.So(output, should.Contain, `msg="dry run summary" new=1 modified=1 unchanged=3 removed=1`+"\n")
+}
+
+func (this *PipelineRunnerFixture) TestDryRunStillReportsPipelineErrors() {
+	this.arg("-dry-run")
+	this.file("content/d.md", "not an article")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+}
+
+func (this *PipelineRunnerFixture) TestJSONReportWritten() {
+	this.arg("-report", "report.json")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	var report BuildReport
+	this.So(json.Unmarshal([]byte(this.disk.Content("report.json")), &report), should.BeNil)
+	this.So(report.Published, should.Equal, 2)
+	this.So(report.Dropped, should.Equal, 1)
+	this.So(report.Articles, should.Contain, ArticleReport{
+		Path: "content/a.md", Slug: "/article-a/", Outcome: OutcomePublished,
+	})
+}
+
+func (this *PipelineRunnerFixture) TestJUnitReportWritten() {
+	this.arg("-report", "report.xml", "-report-format", "junit")
+	this.file("content/d.md", "not an article")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.disk.Content("report.xml"), should.Contain,
+		`<testcase classname="content" name="content/d.md">`)
+}
+
+func (this *PipelineRunnerFixture) TestReportWriteFailureCountsAsError() {
+	this.arg("-report", "report.json")
+	this.disk.ErrWriteFile["report.json"] = errors.New("boink")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+}
+
+func (this *PipelineRunnerFixture) TestStageBreakdownReportedByDefault() {
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	output := this.log.String()
+	this.So(output, should.Contain, `msg="stage finished" stage=1 handler=FileReadingHandler `)
+	this.So(output, should.Contain, `msg="stage finished" stage=14 handler=HomepageRenderingHandler `)
+	this.So(output, should.NOT.Contain, `msg="slowest articles"`)
+}
+
+func (this *PipelineRunnerFixture) TestFailedArticleLoggedWithHandler() {
+	this.file("content/d.md", "not an article")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.log.String(), should.Contain, `level=ERROR msg="article failed" path=content/d.md handler=MetadataParsingHandler`)
+}
+
+func (this *PipelineRunnerFixture) TestJSONLogFormat() {
+	this.arg("-log-format", "json")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	for line := range strings.Lines(this.log.String()) {
+		var record map[string]any
+		this.So(json.Unmarshal([]byte(line), &record), should.BeNil)
+		this.So(record, should.Contain, "level")
+	}
+	this.So(this.log.String(), should.Contain, `"msg":"published article","path":"content/a.md","slug":"/article-a/"`)
+}
+
+func (this *PipelineRunnerFixture) TestLogLevelWarnSilencesInfo() {
+	this.arg("-log-level", "warn")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.NOT.Contain, "level=INFO")
+	this.So(this.log.String(), should.Contain, `level=WARN msg="site warning" warning="topic used by too few articles`)
+}
+
+func (this *PipelineRunnerFixture) TestTopicMinimumAndOrderConfigurable() {
+	this.arg("-topic-min", "1", "-topic-order", "popularity")
+	this.file("templates/topics.tmpl", "{{ range .Topics }}{{ .Topic }}={{ .Count }} {{ end }}")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/topics/index.html", "important=2 misc=1 ")
+	this.So(this.log.String(), should.NOT.Contain, "site warning")
+}
+
+func (this *PipelineRunnerFixture) TestWarningsReportedSeparately() {
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.Contain, `msg="build finished" errors=0 warnings=1 dropped=1 published=2`)
+	this.assertRenderedDiskState()
+}
+
+func (this *PipelineRunnerFixture) TestFailOnWarnings() {
+	this.arg("-fail-on-warnings")
+	_ = this.disk.MkdirAll("rendered", 0755)
+	this.file("rendered/index.html", "LIVE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.assertFile("rendered/index.html", "LIVE")
+}
+
+func (this *PipelineRunnerFixture) TestExitCodeNotTruncated() {
+	this.arg("-fail-on-warnings")
+	for x := range 256 {
+		this.file(fmt.Sprintf("content/x-%d.md", x), fmt.Sprintf(
+			"slug: /x-%d/\ntitle: X\ndate: 2021-01-01\n\n+++\n\nContent.", x))
+	}
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.log.String(), should.Contain, `msg="build finished" errors=0 warnings=257`)
+}
+
+func (this *PipelineRunnerFixture) TestSlowestArticlesReportedWhenDebugging() {
+	this.arg("-log-level", "debug")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.Contain, `level=DEBUG msg="slowest articles" stage=1 handler=FileReadingHandler`)
+}
+
+func (this *PipelineRunnerFixture) TestBrokenLinksReportedAgainstArticle() {
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "See [the old article](/old-slug/).", 1))
+	_ = this.disk.MkdirAll("rendered", 0755)
+	this.file("rendered/index.html", "LIVE")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.log.String(), should.Contain,
+		`error="[content/a.md] broken link: [/old-slug/] on page [article-a/index.html] (no such page)"`)
+	this.assertFile("rendered/index.html", "LIVE")
+}
+
+func (this *PipelineRunnerFixture) TestLinksToAllowedPrefixesNotReported() {
+	this.arg("-allow-links", "/img/, /favicon.ico")
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "![logo](/img/logo.png)", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.NOT.Contain, "broken link")
+}
+
+func (this *PipelineRunnerFixture) TestLinkToUnlistedTopicIsAWarning() {
+	this.file("templates/article.tmpl", `{{ range .Topics }}<a href="/topics/#{{ . }}">{{ . }}</a>{{ end }}`)
+	this.file("templates/topics.tmpl", `{{ range .Topics }}<h2 id="{{ .Topic }}">{{ .Topic }}</h2>{{ end }}`)
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.Contain, `level=WARN msg="article warning" path=content/a.md slug=/article-a/ `+
+		`warning="[content/a.md] broken link: [/topics/#misc] on page [article-a/index.html] (no such anchor)"`)
+	this.So(this.log.String(), should.NOT.Contain, "#important")
+	this.assertFolder("rendered/article-a")
+}
+
+func (this *PipelineRunnerFixture) TestLinkToUnlistedTopicFailsOnWarnings() {
+	this.arg("-fail-on-warnings")
+	this.file("templates/article.tmpl", `{{ range .Topics }}<a href="/topics/#{{ . }}">{{ . }}</a>{{ end }}`)
+	this.file("templates/topics.tmpl", `{{ range .Topics }}<h2 id="{{ .Topic }}">{{ .Topic }}</h2>{{ end }}`)
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/article-a")
+}
+
+func (this *PipelineRunnerFixture) TestArticleWithBrokenLinksReportedOnce() {
+	this.arg("-report", "report.json")
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "See [one](/old-slug/) and [two](/older-slug/).", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	var report BuildReport
+	this.So(json.Unmarshal([]byte(this.disk.Content("report.json")), &report), should.BeNil)
+	this.So(report.Published, should.Equal, 1)
+	this.So(report.Errors, should.Equal, 1)
+	this.So(len(report.Articles), should.Equal, 3)
+	this.So(report.Articles, should.Contain, ArticleReport{
+		Path:    "content/a.md",
+		Slug:    "/article-a/",
+		Outcome: OutcomeError,
+		Error: "[content/a.md] broken link: [/old-slug/] on page [article-a/index.html] (no such page)\n" +
+			"[content/a.md] broken link: [/older-slug/] on page [article-a/index.html] (no such page)",
+	})
+}
+
+func (this *PipelineRunnerFixture) TestLinksBetweenContentFilesResolved() {
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "See [the second article](./b.md).", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.disk.Content("rendered/article-a/index.html"), should.Contain,
+		`<p>See <a href="/article-b/">the second article</a>.</p>`)
+}
+
+func (this *PipelineRunnerFixture) TestLinksToHeadingsResolved() {
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "See [setup](./b.md#setup) and [[article-b#setup|again]].", 1))
+	this.file("content/b.md", strings.Replace(ContentB,
+		"This is the second article.", "## Setup\n\nThis is the second article.", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.log.String(), should.NOT.Contain, "broken link")
+	this.So(this.disk.Content("rendered/article-b/index.html"), should.Contain, `<h2 id="setup">Setup</h2>`)
+	this.So(this.disk.Content("rendered/article-a/index.html"), should.Contain,
+		`<p>See <a href="/article-b/#setup">setup</a> and <a href="/article-b/#setup">again</a>.</p>`)
+}
+
+func (this *PipelineRunnerFixture) TestLinkToDroppedArticleIsAnError() {
+	this.file("content/a.md", strings.Replace(ContentA,
+		"This is the first article.", "See [the draft](c.md).", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.log.String(), should.Contain,
+		`error="[content/a.md] unresolved article link: [c.md] (article was dropped)"`)
+}
+
+func (this *PipelineRunnerFixture) TestPagesRenderedButNotListed() {
+	this.file("content/about.md", "slug: /about/\ntitle: About\nintro: Who?\nkind: page\ntopics: important\n\n+++\n\nAbout me.\n")
+	this.file("templates/page.tmpl", "PAGE {{ .Title }}: {{ .Content }}")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/about/index.html", "PAGE About: <p>About me.</p>")
+	this.assertFile("rendered/index.html", RenderedListDescending)
+	this.assertFile("rendered/archives/index.html", RenderedListDescending)
+	this.assertFile("rendered/topics/index.html", RenderedTopics)
+}
+
+func (this *PipelineRunnerFixture) TestPinnedArticlesListedAboveRecentOnes() {
+	this.arg("-home-recent", "1")
+	this.file("content/a.md", strings.Replace(ContentA, "title:", "pinned: true\ntitle:", 1))
+	this.file("templates/home.tmpl", "{{ range .Pinned }}pinned {{ .Slug }}\n{{ end }}{{ range .Recent }}recent {{ .Slug }}\n{{ end }}")
+	this.file("content/d.md", strings.Replace(ContentB, "/article-b/", "/article-d/", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/index.html", "pinned /article-a/\nrecent /article-b/\n")
+}
+
+func (this *PipelineRunnerFixture) TestAliasesRedirectToSlug() {
+	this.arg("-base-path", "/blog")
+	this.file("content/a.md", strings.Replace(ContentA, "title:", "aliases: /old-a/\ntitle:", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.disk.Content("rendered/old-a/index.html"), should.Contain, `<a href="/blog/article-a/">`)
+	this.assertFile("rendered/_redirects", "/blog/old-a/ /blog/article-a/ 301\n")
+	this.So(this.disk.Paths(), should.Contain, "rendered/nginx-redirects.conf")
+}
+
+func (this *PipelineRunnerFixture) TestAliasCollidingWithSlug_Err() {
+	this.file("content/a.md", strings.Replace(ContentA, "title:", "aliases: /article-b/\ntitle:", 1))
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 1)
+	this.So(this.log.String(), should.Contain, "[content/b.md] repeated metadata slug (already an alias in content/a.md)")
+}
+
+func (this *PipelineRunnerFixture) TestNotFoundArticleWrittenTo404HTML() {
+	this.arg("-base-path", "/blog")
+	this.file("content/404.md", "title: Lost?\nintro: Try the [home page].\nkind: not-found\n\n+++\n\nTry [the first article](article-a/).\n")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.So(this.disk.Content("rendered/404.html"), should.Contain,
+		`<p>Try <a href="/blog/article-a/">the first article</a>.</p>`)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/404.html/index.html")
+	this.assertFile("rendered/index.html", RenderedListDescending)
+	this.So(this.log.String(), should.Contain, `msg="published article" path=content/404.md slug=/404.html`)
+}
+
+func (this *PipelineRunnerFixture) TestNotFoundTemplateWrittenTo404HTML() {
+	this.file("templates/404.tmpl", "NOT FOUND: {{ .Slug }}")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	this.assertFile("rendered/404.html", "NOT FOUND: /404.html")
+}
+
+func (this *PipelineRunnerFixture) TestTraceWritten() {
+	this.arg("-trace", "trace.json")
+
+	errs := this.buildRunner().Run()
+
+	this.So(errs, should.Equal, 0)
+	var trace struct {
+		Events []struct {
+			Name  string `json:"name"`
+			Phase string `json:"ph"`
+			TID   int    `json:"tid"`
+		} `json:"traceEvents"`
+	}
+	this.So(json.Unmarshal([]byte(this.disk.Content("trace.json")), &trace), should.BeNil)
+	this.So(len(trace.Events), should.BeGreaterThan, 0)
+	this.So(trace.Events[0].Phase, should.Equal, "M")
+}
+
 func (this *PipelineRunnerFixture) assertRenderedDiskState() {
-	this.So(len(this.disk.Files), should.Equal, 17)
-	files, _ := json.MarshalIndent(this.disk.Files, "", "  ")
+	this.So(len(this.disk.Files()), should.Equal, 12) // 3 articles, 4 templates, 5 rendered pages
+	files, _ := json.MarshalIndent(this.disk.Paths(), "", "  ")
 	this.Println("FILES:", string(files))
 
 	this.assertFolder("rendered")
@@ -143,7 +614,7 @@ func (this *PipelineRunnerFixture) assertRenderedDiskState() {
 }
 
 func (this *PipelineRunnerFixture) assertOriginalDiskState() {
-	this.So(len(this.disk.Files), should.Equal, 7) // 3 articles, 4 templates
+	this.So(len(this.disk.Files()), should.Equal, 7) // 3 articles, 4 templates
 }
 
 const (
@@ -185,7 +656,7 @@ This is the third article.
 `
 
 	TemplateHome = `
-{{ range .Pages }}
+{{ range .Recent }}
 Slug:   {{ .Slug }}
 Title:  {{ .Title }}
 Date:   {{ .Date.Format "2006-01-02" }}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+type PruningFileSystem interface {
+	contracts.Walk
+	contracts.RemoveAll
+}
+
+// Pruner finds (and optionally removes) whatever is in the target
+// directory that the most recent build didn't produce, except for files
+// placed there by hand (under the kept url path prefixes, see -allow-links).
+type Pruner struct {
+	disk     PruningFileSystem
+	target   string
+	produced map[string]struct{}
+	kept     []string
+}
+
+func NewPruner(disk PruningFileSystem, target string, produced map[string]struct{}, kept []string) *Pruner {
+	return &Pruner{
+		disk:     disk,
+		target:   target,
+		produced: produced,
+		kept:     kept,
+	}
+}
+
+// Stale lists the paths beneath the target that weren't produced. When a
+// whole directory is stale, only the directory itself is listed.
+func (this *Pruner) Stale() (stale []string, err error) {
+	for entry := range this.disk.Walk(this.target) {
+		if errors.Is(entry.Error, fs.ErrNotExist) && entry.Path == this.target {
+			continue
+		}
+		if entry.Error != nil {
+			err = entry.Error
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		rel, _ := filepath.Rel(this.target, entry.Path)
+		if rel == "." || this.isProduced(rel) || this.isKept(rel, entry.IsDir()) || isWithinAny(entry.Path, stale) {
+			continue
+		}
+		stale = append(stale, entry.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stale, nil
+}
+
+// Prune removes the stale paths, returning the ones actually removed.
+func (this *Pruner) Prune() (removed []string, err error) {
+	stale, err := this.Stale()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range stale {
+		err = this.disk.RemoveAll(path)
+		if err != nil {
+			return removed, err
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+func (this *Pruner) isProduced(rel string) bool {
+	_, found := this.produced[rel]
+	return found
+}
+
+// isKept reports whether rel is a kept file, or a directory that may hold
+// kept files (whose other contents are then considered one by one).
+func (this *Pruner) isKept(rel string, dir bool) bool {
+	rel = filepath.ToSlash(rel)
+	if isAllowedLink(this.kept, rel) {
+		return true
+	}
+	return dir && slices.ContainsFunc(this.kept, func(prefix string) bool {
+		return strings.HasPrefix(prefix, "/"+rel+"/")
+	})
+}
+func isWithinAny(path string, stale []string) bool {
+	path = filepath.Clean(path)
+	for _, dir := range stale {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestPrunerFixture(t *testing.T) {
+	suite.Run(&PrunerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type PrunerFixture struct {
+	*suite.T
+
+	disk   *FakeFileSystem
+	pruner *Pruner
+}
+
+func (this *PrunerFixture) Setup() {
+	this.disk = NewFakeFileSystem()
+	this.pruner = NewPruner(this.disk, "rendered", map[string]struct{}{
+		"index.html":   {},
+		"a":            {},
+		"a/index.html": {},
+	}, []string{"/img/", "/a/kept/", "/favicon.ico"})
+
+	_ = this.disk.MkdirAll("rendered/a", 0755)
+	this.disk.Write("rendered/index.html", "")
+	this.disk.Write("rendered/a/index.html", "")
+	this.disk.Write("rendered/a/old.html", "")
+	_ = this.disk.MkdirAll("rendered/deleted/nested", 0755)
+	this.disk.Write("rendered/deleted/index.html", "")
+	this.disk.Write("rendered/deleted/nested/index.html", "")
+}
+
+func (this *PrunerFixture) TestStaleListsOnlyTopmostUnproducedPaths() {
+	stale, err := this.pruner.Stale()
+
+	this.So(err, should.BeNil)
+	this.So(stale, should.Equal, []string{
+		"rendered/a/old.html",
+		"rendered/deleted",
+	})
+	this.So(this.disk.Paths(), should.Contain, "rendered/deleted/index.html")
+}
+
+func (this *PrunerFixture) TestPruneRemovesStalePaths() {
+	removed, err := this.pruner.Prune()
+
+	this.So(err, should.BeNil)
+	this.So(removed, should.Equal, []string{"rendered/a/old.html", "rendered/deleted"})
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/a/old.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/deleted/nested/index.html")
+	this.So(this.disk.Paths(), should.Contain, "rendered/a/index.html")
+	this.So(this.disk.Paths(), should.Contain, "rendered/index.html")
+}
+
+func (this *PrunerFixture) TestKeptFilesNeverStale() {
+	this.disk.Write("rendered/img/logo.png", "")
+	this.disk.Write("rendered/favicon.ico", "")
+	this.disk.Write("rendered/a/kept/notes.txt", "")
+	this.disk.Write("rendered/a/kept-not/notes.txt", "")
+	this.disk.Write("rendered/favicon.png", "")
+
+	stale, err := this.pruner.Stale()
+
+	this.So(err, should.BeNil)
+	this.So(stale, should.Equal, []string{
+		"rendered/a/kept-not",
+		"rendered/a/old.html",
+		"rendered/deleted",
+		"rendered/favicon.png",
+	})
+}
+
+func (this *PrunerFixture) TestMissingTargetHasNothingStale() {
+	this.pruner = NewPruner(this.disk, "missing", nil, nil)
+
+	stale, err := this.pruner.Stale()
+
+	this.So(err, should.BeNil)
+	this.So(stale, should.BeEmpty)
+}
+
+func (this *PrunerFixture) TestWalkError() {
+	walkErr := errors.New("boink")
+	this.disk.ErrWalkFunc["rendered/a/old.html"] = walkErr
+
+	removed, err := this.pruner.Prune()
+
+	this.So(err, should.WrapError, walkErr)
+	this.So(removed, should.BeEmpty)
+	this.So(this.disk.Paths(), should.Contain, "rendered/deleted")
+}
+
+func (this *PrunerFixture) TestRemoveError() {
+	removeErr := errors.New("boink")
+	this.disk.ErrRemoveAll["rendered/deleted"] = removeErr
+
+	removed, err := this.pruner.Prune()
+
+	this.So(err, should.WrapError, removeErr)
+	this.So(removed, should.Equal, []string{"rendered/a/old.html"})
+}
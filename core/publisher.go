@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+type PublishingFileSystem interface {
+	contracts.ReadFile
+	contracts.WriteFile
+	contracts.MkdirAll
+	contracts.RemoveAll
+	contracts.Rename
+	contracts.Walk
+}
+
+// Publisher stages a build in a sibling of the target directory and only
+// swaps it into place once the build is known to be good. The directory
+// it replaces is kept (with a ".prev" suffix) for quick rollback. Files
+// placed in the target by hand (under the kept url path prefixes, see
+// -allow-links) are carried over into each new build.
+type Publisher struct {
+	disk     PublishingFileSystem
+	target   string
+	staging  string
+	previous string
+	kept     []string
+}
+
+func NewPublisher(disk PublishingFileSystem, target string, kept []string) *Publisher {
+	target = filepath.Clean(target)
+	return &Publisher{
+		disk:     disk,
+		target:   target,
+		staging:  target + ".staging",
+		previous: target + ".prev",
+		kept:     kept,
+	}
+}
+
+// Prepare clears away anything left behind by an interrupted build and
+// returns the staging directory to render into, holding only the kept
+// files copied from the target.
+func (this *Publisher) Prepare() (staging string, err error) {
+	err = this.disk.RemoveAll(this.staging)
+	if err != nil {
+		return "", err
+	}
+	err = this.disk.MkdirAll(this.staging, 0755)
+	if err != nil {
+		return "", err
+	}
+	err = this.seed()
+	if err != nil {
+		return "", err
+	}
+	return this.staging, nil
+}
+
+// seed copies the kept files from the target into the staging directory
+// (where the build may still overwrite them).
+func (this *Publisher) seed() (err error) {
+	if len(this.kept) == 0 {
+		return nil
+	}
+	for entry := range this.disk.Walk(this.target) {
+		if errors.Is(entry.Error, fs.ErrNotExist) && entry.Path == this.target {
+			continue
+		}
+		if entry.Error != nil {
+			err = entry.Error
+			continue
+		}
+		rel, _ := filepath.Rel(this.target, entry.Path)
+		if err != nil || entry.IsDir() || !isAllowedLink(this.kept, filepath.ToSlash(rel)) {
+			continue
+		}
+		err = this.copy(entry.Path, filepath.Join(this.staging, rel))
+	}
+	return err
+}
+func (this *Publisher) copy(from, to string) error {
+	content, err := this.disk.ReadFile(from)
+	if err != nil {
+		return err
+	}
+	err = this.disk.MkdirAll(filepath.Dir(to), 0755)
+	if err != nil {
+		return err
+	}
+	return this.disk.WriteFile(to, content, 0644)
+}
+
+// Publish swaps the staging directory into place with two renames: the
+// current target to ".prev", then the staging directory to the target.
+// Between them there is (briefly) no target at all, but never a partly
+// written one. Should the second rename fail, the previous target is
+// restored.
+func (this *Publisher) Publish() error {
+	err := this.disk.RemoveAll(this.previous)
+	if err != nil {
+		return err
+	}
+	err = this.disk.Rename(this.target, this.previous)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	err = this.disk.Rename(this.staging, this.target)
+	if err != nil {
+		_ = this.disk.Rename(this.previous, this.target)
+		return err
+	}
+	return nil
+}
+
+// Abandon discards the staging directory, leaving the target untouched.
+func (this *Publisher) Abandon() error {
+	return this.disk.RemoveAll(this.staging)
+}
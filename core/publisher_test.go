@@ -0,0 +1,134 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestPublisherFixture(t *testing.T) {
+	suite.Run(&PublisherFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type PublisherFixture struct {
+	*suite.T
+
+	disk      *FakeFileSystem
+	publisher *Publisher
+}
+
+func (this *PublisherFixture) Setup() {
+	this.disk = NewFakeFileSystem()
+	this.publisher = NewPublisher(this.disk, "rendered/", []string{"/img/", "/favicon.ico"})
+
+	_ = this.disk.MkdirAll("rendered/live", 0755)
+	this.disk.Write("rendered/live/index.html", "LIVE")
+}
+
+func (this *PublisherFixture) stage() {
+	staging, err := this.publisher.Prepare()
+	this.So(err, should.BeNil)
+	this.So(staging, should.Equal, "rendered.staging")
+	_ = this.disk.MkdirAll("rendered.staging/next", 0755)
+	this.disk.Write("rendered.staging/next/index.html", "NEXT")
+}
+
+func (this *PublisherFixture) TestPrepareClearsLeftoverStaging() {
+	_ = this.disk.MkdirAll("rendered.staging/leftover", 0755)
+
+	staging, err := this.publisher.Prepare()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Paths(), should.Contain, staging)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.staging/leftover")
+}
+
+func (this *PublisherFixture) TestPrepareCopiesKeptFiles() {
+	this.disk.Write("rendered/img/logo.png", "LOGO")
+	this.disk.Write("rendered/favicon.ico", "ICON")
+	this.disk.Write("rendered/imgs/other.png", "OTHER")
+
+	staging, err := this.publisher.Prepare()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Content(staging+"/img/logo.png"), should.Equal, "LOGO")
+	this.So(this.disk.Content(staging+"/favicon.ico"), should.Equal, "ICON")
+	this.So(this.disk.Paths(), should.NOT.Contain, staging+"/imgs/other.png")
+	this.So(this.disk.Paths(), should.NOT.Contain, staging+"/live/index.html")
+}
+
+func (this *PublisherFixture) TestPrepareWithoutExistingTarget() {
+	_ = this.disk.RemoveAll("rendered")
+
+	staging, err := this.publisher.Prepare()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Paths(), should.Contain, staging)
+}
+
+func (this *PublisherFixture) TestPrepareFailsToCopyKeptFiles() {
+	readErr := errors.New("boink")
+	this.disk.Write("rendered/img/logo.png", "LOGO")
+	this.disk.ErrReadFile["rendered/img/logo.png"] = readErr
+
+	_, err := this.publisher.Prepare()
+
+	this.So(err, should.WrapError, readErr)
+}
+
+func (this *PublisherFixture) TestPublishSwapsStagingIntoPlace() {
+	this.stage()
+
+	err := this.publisher.Publish()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Content("rendered/next/index.html"), should.Equal, "NEXT")
+	this.So(this.disk.Content("rendered.prev/live/index.html"), should.Equal, "LIVE")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered/live/index.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.staging")
+}
+
+func (this *PublisherFixture) TestPublishReplacesOlderPrevious() {
+	_ = this.disk.MkdirAll("rendered.prev/ancient", 0755)
+	this.stage()
+
+	err := this.publisher.Publish()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.prev/ancient")
+	this.So(this.disk.Paths(), should.Contain, "rendered.prev/live")
+}
+
+func (this *PublisherFixture) TestPublishWithoutExistingTarget() {
+	_ = this.disk.RemoveAll("rendered")
+	this.stage()
+
+	err := this.publisher.Publish()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Paths(), should.Contain, "rendered/next/index.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.prev")
+}
+
+func (this *PublisherFixture) TestFailedSwapRestoresTarget() {
+	this.stage()
+	renameErr := errors.New("boink")
+	this.disk.ErrRename["rendered.staging"] = renameErr
+
+	err := this.publisher.Publish()
+
+	this.So(err, should.WrapError, renameErr)
+	this.So(this.disk.Content("rendered/live/index.html"), should.Equal, "LIVE")
+}
+
+func (this *PublisherFixture) TestAbandonLeavesTargetUntouched() {
+	this.stage()
+
+	err := this.publisher.Abandon()
+
+	this.So(err, should.BeNil)
+	this.So(this.disk.Paths(), should.NOT.Contain, "rendered.staging")
+	this.So(this.disk.Content("rendered/live/index.html"), should.Equal, "LIVE")
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// RecordingFileSystem decorates a contracts.FileSystem, passing reads
+// through but only recording (never performing) writes, so that a build
+// can be previewed without touching the disk.
+type RecordingFileSystem struct {
+	contracts.FileSystem
+
+	lock  sync.Mutex
+	files map[string][]byte
+}
+
+func NewRecordingFileSystem(inner contracts.FileSystem) *RecordingFileSystem {
+	return &RecordingFileSystem{
+		FileSystem: inner,
+		files:      make(map[string][]byte),
+	}
+}
+
+func (this *RecordingFileSystem) ReadFile(path string) ([]byte, error) {
+	this.lock.Lock()
+	content, found := this.files[filepath.Clean(path)]
+	this.lock.Unlock()
+	if found {
+		return content, nil
+	}
+	return this.FileSystem.ReadFile(path)
+}
+func (this *RecordingFileSystem) WriteFile(path string, content []byte, _ os.FileMode) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.files[filepath.Clean(path)] = bytes.Clone(content)
+	return nil
+}
+func (this *RecordingFileSystem) MkdirAll(string, os.FileMode) error { return nil }
+func (this *RecordingFileSystem) RemoveAll(string) error             { return nil }
+func (this *RecordingFileSystem) Rename(string, string) error        { return nil }
+
+// Compare sorts each recorded write by how it relates to what is
+// currently on the inner file system.
+func (this *RecordingFileSystem) Compare() (changes RecordedChanges, err error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for _, path := range slices.Sorted(maps.Keys(this.files)) {
+		existing, readErr := this.FileSystem.ReadFile(path)
+		switch {
+		case errors.Is(readErr, fs.ErrNotExist):
+			changes.New = append(changes.New, path)
+		case readErr != nil:
+			err = errors.Join(err, readErr)
+		case bytes.Equal(existing, this.files[path]):
+			changes.Unchanged = append(changes.Unchanged, path)
+		default:
+			changes.Modified = append(changes.Modified, path)
+		}
+	}
+	return changes, err
+}
+
+type RecordedChanges struct {
+	New       []string
+	Modified  []string
+	Unchanged []string
+}
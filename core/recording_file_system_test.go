@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestRecordingFileSystemFixture(t *testing.T) {
+	suite.Run(&RecordingFileSystemFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type RecordingFileSystemFixture struct {
+	*suite.T
+
+	inner    *FakeFileSystem
+	recorder *RecordingFileSystem
+}
+
+func (this *RecordingFileSystemFixture) Setup() {
+	this.inner = NewFakeFileSystem()
+	this.recorder = NewRecordingFileSystem(this.inner)
+
+	_ = this.inner.MkdirAll("rendered", 0755)
+	this.inner.Write("rendered/same.html", "SAME")
+	this.inner.Write("rendered/changed.html", "BEFORE")
+}
+
+func (this *RecordingFileSystemFixture) TestWritesRecordedButNotPerformed() {
+	this.So(this.recorder.MkdirAll("rendered/new", 0755), should.BeNil)
+	this.So(this.recorder.WriteFile("rendered/new/index.html", []byte("NEW"), 0644), should.BeNil)
+	this.So(this.recorder.RemoveAll("rendered"), should.BeNil)
+	this.So(this.recorder.Rename("rendered", "elsewhere"), should.BeNil)
+
+	this.So(this.inner.Paths(), should.Equal, []string{"rendered", "rendered/changed.html", "rendered/same.html"})
+	content, err := this.recorder.ReadFile("rendered/new/index.html")
+	this.So(err, should.BeNil)
+	this.So(string(content), should.Equal, "NEW")
+}
+
+func (this *RecordingFileSystemFixture) TestCompare() {
+	_ = this.recorder.WriteFile("rendered/same.html", []byte("SAME"), 0644)
+	_ = this.recorder.WriteFile("rendered/changed.html", []byte("AFTER"), 0644)
+	_ = this.recorder.WriteFile("rendered/new.html", []byte("NEW"), 0644)
+
+	changes, err := this.recorder.Compare()
+
+	this.So(err, should.BeNil)
+	this.So(changes, should.Equal, RecordedChanges{
+		New:       []string{"rendered/new.html"},
+		Modified:  []string{"rendered/changed.html"},
+		Unchanged: []string{"rendered/same.html"},
+	})
+}
+
+func (this *RecordingFileSystemFixture) TestCompareReadError() {
+	readErr := errors.New("boink")
+	this.inner.ErrReadFile["rendered/same.html"] = readErr
+	_ = this.recorder.WriteFile("rendered/same.html", []byte("SAME"), 0644)
+
+	_, err := this.recorder.Compare()
+
+	this.So(err, should.WrapError, readErr)
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"html/template"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+const (
+	// NetlifyRedirectsFile lists every alias for Netlify (and compatible hosts).
+	NetlifyRedirectsFile = "_redirects"
+
+	// NginxRedirectsFile lists every alias as an nginx map, to be included
+	// in the http block and applied with, e.g.:
+	//
+	//	if ($hugoinho_redirect) { return 301 $hugoinho_redirect; }
+	NginxRedirectsFile = "nginx-redirects.conf"
+)
+
+// RedirectRenderingHandler writes a small page at each alias (old slug) of
+// each article, which sends the browser (and search engines) on to the
+// article's slug. Once every article has streamed past, it also lists the
+// aliases for hosts that can redirect by themselves.
+type RedirectRenderingHandler struct {
+	disk      RenderingFileSystem
+	basePath  string
+	output    string
+	redirects []redirect
+}
+
+type redirect struct {
+	from string
+	to   string
+}
+
+func NewRedirectRenderingHandler(disk RenderingFileSystem, basePath string, output string) *RedirectRenderingHandler {
+	return &RedirectRenderingHandler{
+		disk:     disk,
+		basePath: basePath,
+		output:   output,
+	}
+}
+
+func (this *RedirectRenderingHandler) Handle(article *contracts.Article) {
+	to := this.url(article.Metadata.Slug)
+	for _, alias := range article.Metadata.Aliases {
+		err := this.render(alias, to)
+		if err != nil {
+			article.Error = fmt.Errorf("[%s] %w", article.Source.Path, err)
+			return
+		}
+		this.redirects = append(this.redirects, redirect{from: this.url(alias), to: to})
+	}
+}
+
+func (this *RedirectRenderingHandler) render(alias, to string) error {
+	var rendered bytes.Buffer
+	err := redirectPage.Execute(&rendered, to)
+	if err != nil {
+		return err
+	}
+
+	folder := filepath.Join(this.output, alias)
+	err = this.disk.MkdirAll(folder, 0755)
+	if err != nil {
+		return err
+	}
+
+	return this.disk.WriteFile(filepath.Join(folder, "index.html"), rendered.Bytes(), 0644)
+}
+
+func (this *RedirectRenderingHandler) Finalize() error {
+	if len(this.redirects) == 0 {
+		return nil
+	}
+	slices.SortFunc(this.redirects, func(a, b redirect) int { return cmp.Compare(a.from, b.from) })
+
+	var netlify, nginx strings.Builder
+	nginx.WriteString("map $uri $hugoinho_redirect {\n")
+	for _, redirect := range this.redirects {
+		fmt.Fprintf(&netlify, "%s %s 301\n", redirect.from, redirect.to)
+		fmt.Fprintf(&nginx, "    %s %s;\n", redirect.from, redirect.to)
+		fmt.Fprintf(&nginx, "    %s %s;\n", strings.TrimSuffix(redirect.from, "/"), redirect.to)
+	}
+	nginx.WriteString("}\n")
+
+	err := this.disk.MkdirAll(this.output, 0755)
+	if err != nil {
+		return err
+	}
+	return errors.Join(
+		this.disk.WriteFile(filepath.Join(this.output, NetlifyRedirectsFile), []byte(netlify.String()), 0644),
+		this.disk.WriteFile(filepath.Join(this.output, NginxRedirectsFile), []byte(nginx.String()), 0644),
+	)
+}
+
+// url is the absolute (under the base path) url of the page at slug.
+func (this *RedirectRenderingHandler) url(slug string) string {
+	return strings.TrimSuffix(path.Join("/", this.basePath, slug), "/") + "/"
+}
+
+var redirectPage = template.Must(template.New("redirect").Parse(`<!doctype html>
+<html lang="en">
+    <head>
+        <meta charset="utf-8">
+        <title>Redirecting to {{ . }}</title>
+        <link rel="canonical" href="{{ . }}">
+        <meta name="robots" content="noindex">
+        <meta http-equiv="refresh" content="0; url={{ . }}">
+    </head>
+    <body>
+        <p>This page has moved to <a href="{{ . }}">{{ . }}</a>.</p>
+    </body>
+</html>
+`))
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestRedirectRenderingHandlerFixture(t *testing.T) {
+	suite.Run(&RedirectRenderingHandlerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type RedirectRenderingHandlerFixture struct {
+	*suite.T
+
+	handler *RedirectRenderingHandler
+	disk    *FakeFileSystem
+}
+
+func (this *RedirectRenderingHandlerFixture) Setup() {
+	this.disk = NewFakeFileSystem()
+	this.handler = NewRedirectRenderingHandler(this.disk, "/base", "output/folder")
+}
+
+func (this *RedirectRenderingHandlerFixture) handle(slug string, aliases ...string) *contracts.Article {
+	article := &contracts.Article{
+		Source:   contracts.ArticleSource{Path: "content/article.md"},
+		Metadata: contracts.ArticleMetadata{Slug: slug, Aliases: aliases},
+	}
+	this.handler.Handle(article)
+	return article
+}
+
+func (this *RedirectRenderingHandlerFixture) TestNoAliases_NothingWritten() {
+	this.handle("/a")
+
+	this.So(this.handler.Finalize(), should.BeNil)
+	this.So(this.disk.Paths(), should.BeEmpty)
+}
+
+func (this *RedirectRenderingHandlerFixture) TestRedirectPageWrittenAtEachAlias() {
+	article := this.handle("/new/", "/old/", "/older")
+
+	this.So(article.Error, should.BeNil)
+	this.So(this.disk.Paths(), should.Contain, "output/folder/old/index.html")
+	this.So(this.disk.Paths(), should.Contain, "output/folder/older/index.html")
+	page := this.disk.Content("output/folder/older/index.html")
+	this.So(page, should.Contain, `<link rel="canonical" href="/base/new/">`)
+	this.So(page, should.Contain, `<meta http-equiv="refresh" content="0; url=/base/new/">`)
+	this.So(page, should.Contain, `<a href="/base/new/">/base/new/</a>`)
+}
+
+func (this *RedirectRenderingHandlerFixture) TestRedirectListsWrittenInOrder() {
+	this.handle("/b", "/old-b/")
+	this.handle("/a/", "/old-a")
+
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	this.So(this.disk.Content("output/folder/_redirects"), should.Equal, ""+
+		"/base/old-a/ /base/a/ 301\n"+
+		"/base/old-b/ /base/b/ 301\n")
+	this.So(this.disk.Content("output/folder/nginx-redirects.conf"), should.Equal, ""+
+		"map $uri $hugoinho_redirect {\n"+
+		"    /base/old-a/ /base/a/;\n"+
+		"    /base/old-a /base/a/;\n"+
+		"    /base/old-b/ /base/b/;\n"+
+		"    /base/old-b /base/b/;\n"+
+		"}\n")
+}
+
+func (this *RedirectRenderingHandlerFixture) TestWithoutBasePath() {
+	this.handler = NewRedirectRenderingHandler(this.disk, "", "output/folder")
+	this.handle("/a/", "/old-a/")
+
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	this.So(this.disk.Content("output/folder/_redirects"), should.Equal, "/old-a/ /a/ 301\n")
+}
+
+func (this *RedirectRenderingHandlerFixture) TestWriteFileErrorReportedAgainstArticle() {
+	writeFileErr := errors.New("boink")
+	this.disk.ErrWriteFile["output/folder/old/index.html"] = writeFileErr
+
+	article := this.handle("/new/", "/old/")
+
+	this.So(article.Error, should.WrapError, writeFileErr)
+	this.So(article.Error.Error(), should.Equal, "[content/article.md] boink")
+	this.So(this.handler.Finalize(), should.BeNil)
+}
+
+func (this *RedirectRenderingHandlerFixture) TestListWriteFileErrorReturned() {
+	writeFileErr := errors.New("boink")
+	this.disk.ErrWriteFile["output/folder/_redirects"] = writeFileErr
+	this.handle("/new/", "/old/")
+
+	this.So(this.handler.Finalize(), should.WrapError, writeFileErr)
+	this.So(this.disk.Paths(), should.Contain, "output/folder/nginx-redirects.conf")
+}
@@ -2,26 +2,47 @@ package core
 
 import (
 	"errors"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/mdw-tools/hugoinho/contracts"
 )
 
 type Reporter struct {
-	log       contracts.Logger
-	started   time.Time
-	errors    int
-	dropped   int
-	published int
+	log      contracts.Logger
+	started  time.Time
+	errors   int
+	warnings int
+	dropped  int
+	passed   int
+	outcome  string
+	articles []ArticleReport
+	site     []string
+	stages   *StageStatistics
+
+	lock     sync.Mutex
+	handlers map[string]string
 }
 
 func NewReporter(started time.Time, log contracts.Logger) *Reporter {
 	return &Reporter{
-		started: started,
-		log:     log,
+		started:  started,
+		log:      log,
+		outcome:  OutcomePublished,
+		stages:   NewStageStatistics(3),
+		handlers: make(map[string]string),
 	}
 }
 
+// NewCheckReporter reports on a check run, which publishes nothing, so its
+// successful articles are "checked" rather than "published".
+func NewCheckReporter(started time.Time, log contracts.Logger) *Reporter {
+	reporter := NewReporter(started, log)
+	reporter.outcome = OutcomeChecked
+	return reporter
+}
+
 func (this *Reporter) ProcessStream(out chan contracts.Article) {
 	for article := range out {
 		this.accountFor(article)
@@ -29,25 +50,155 @@ func (this *Reporter) ProcessStream(out chan contracts.Article) {
 }
 
 func (this *Reporter) accountFor(article contracts.Article) {
+	report := ArticleReport{
+		Path: article.Source.Path,
+		Slug: article.Metadata.Slug,
+	}
+	attributes := this.attributes(article)
 	if errors.Is(article.Error, contracts.ErrDroppedArticle) {
-		this.log.Println("[INFO]", article.Error)
+		this.log.Info("dropped article", append(attributes, "reason", article.Error.Error())...)
 		this.dropped++
+		report.Outcome = OutcomeDropped
+		report.Error = article.Error.Error()
 	} else if article.Error != nil {
-		this.log.Println("[WARN] error:", article.Error)
+		this.log.Error("article failed", append(attributes, "error", article.Error.Error())...)
 		this.errors++
+		report.Outcome = OutcomeError
+		report.Error = article.Error.Error()
 	} else {
-		this.log.Println("[INFO] published article:", article.Metadata.Slug)
-		this.published++
+		this.log.Info(this.outcome+" article", attributes...)
+		this.passed++
+		report.Outcome = this.outcome
+		for _, warning := range article.Warnings {
+			this.log.Warn("article warning", append(attributes, "warning", warning.Error())...)
+			this.warnings++
+			report.Warnings = append(report.Warnings, warning.Error())
+		}
+	}
+	this.articles = append(this.articles, report)
+}
+
+// fail accounts for an error found (e.g. by the link checker) after the
+// article at path was reported, turning its report into a failure (so
+// that it's counted once, however many errors it has).
+func (this *Reporter) fail(path string, err error) {
+	index := slices.IndexFunc(this.articles, func(report ArticleReport) bool {
+		return path != "" && report.Path == path && report.Outcome != OutcomeDropped
+	})
+	if index < 0 {
+		this.accountFor(contracts.Article{Error: err})
+		return
+	}
+	report := &this.articles[index]
+	this.log.Error("article failed", "path", report.Path, "slug", report.Slug, "error", err.Error())
+	switch report.Outcome {
+	case this.outcome:
+		this.passed--
+		this.errors++
+		report.Outcome = OutcomeError
+		report.Error = err.Error()
+	case OutcomeError:
+		report.Error += "\n" + err.Error()
+	}
+}
+
+// warn accounts for a warning found (e.g. by the link checker) after the
+// article at path was reported. An article that already failed needs no
+// further warnings.
+func (this *Reporter) warn(path string, warning error) {
+	index := slices.IndexFunc(this.articles, func(report ArticleReport) bool {
+		return path != "" && report.Path == path && report.Outcome != OutcomeDropped
+	})
+	if index < 0 {
+		this.ProcessWarnings([]error{warning})
+		return
+	}
+	report := &this.articles[index]
+	if report.Outcome != this.outcome {
+		return
+	}
+	this.log.Warn("article warning", "path", report.Path, "slug", report.Slug, "warning", warning.Error())
+	this.warnings++
+	report.Warnings = append(report.Warnings, warning.Error())
+}
+
+// ProcessWarnings accounts for warnings about the site as a whole, rather
+// than any one article (see Pipeline.Warnings).
+func (this *Reporter) ProcessWarnings(warnings []error) {
+	for _, warning := range warnings {
+		this.log.Warn("site warning", "warning", warning.Error())
+		this.warnings++
+		this.site = append(this.site, warning.Error())
+	}
+}
+
+// attributes describes the article (and, when known, the handler that
+// failed or dropped it) for structured log records.
+func (this *Reporter) attributes(article contracts.Article) (attributes []any) {
+	if article.Source.Path != "" {
+		attributes = append(attributes, "path", article.Source.Path)
+	}
+	if article.Metadata.Slug != "" {
+		attributes = append(attributes, "slug", article.Metadata.Slug)
+	}
+	this.lock.Lock()
+	handler, found := this.handlers[article.Source.Path]
+	this.lock.Unlock()
+	if found {
+		attributes = append(attributes, "handler", handler)
+	}
+	return attributes
+}
+
+// Observe accounts for time spent in each pipeline stage, which is
+// included in the final report, and remembers which stage (if any)
+// failed or dropped each article.
+func (this *Reporter) Observe(stage contracts.Stage, article *contracts.Article, started, finished time.Time) {
+	this.stages.Observe(stage, article, started, finished)
+	if article == nil || article.Error == nil || article.Source.Path == "" {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if _, found := this.handlers[article.Source.Path]; !found {
+		this.handlers[article.Source.Path] = stage.Name
 	}
 }
 
 func (this *Reporter) RenderFinalReport(finished time.Time) {
-	this.log.Println("[INFO] errors encountered: ", this.errors)
-	this.log.Println("[INFO] dropped articles:   ", this.dropped)
-	this.log.Println("[INFO] published articles: ", this.published)
-	this.log.Println("[INFO] processing duration:", finished.Sub(this.started).Round(time.Millisecond))
+	this.stages.Render(this.log)
+	this.log.Info("build finished",
+		"errors", this.errors,
+		"warnings", this.warnings,
+		"dropped", this.dropped,
+		this.outcome, this.passed,
+		"duration", finished.Sub(this.started).Round(time.Millisecond),
+	)
+}
+
+func (this *Reporter) Report(finished time.Time) BuildReport {
+	report := BuildReport{
+		Started:      this.started,
+		Finished:     finished,
+		Duration:     finished.Sub(this.started),
+		Errors:       this.errors,
+		Warnings:     this.warnings,
+		Dropped:      this.dropped,
+		Articles:     this.articles,
+		SiteWarnings: this.site,
+	}
+	if this.outcome == OutcomeChecked {
+		report.Checked = this.passed
+	} else {
+		report.Published = this.passed
+	}
+	return report
 }
 
 func (this *Reporter) Errors() int {
 	return this.errors
 }
+
+func (this *Reporter) Warnings() int {
+	return this.warnings
+}
@@ -3,7 +3,6 @@ package core
 import (
 	"bytes"
 	"errors"
-	"log"
 	"strings"
 	"testing"
 	"time"
@@ -29,30 +28,105 @@ func (this *ReporterFixture) Test() {
 	go this.load(stream)
 
 	logger := new(bytes.Buffer)
-	reporter := NewReporter(started, log.New(logger, "", 0))
+	reporter := NewReporter(started, newTestLogger(logger))
+	reporter.Observe(contracts.Stage{Index: 3, Name: "MetadataParsingHandler"}, &contracts.Article{
+		Source: contracts.ArticleSource{Path: "d.md"},
+		Error:  errors.New("GOPHERS"),
+	}, started, started)
 	reporter.ProcessStream(stream)
+	reporter.ProcessWarnings([]error{errors.New("LONELY")})
 	reporter.RenderFinalReport(stopped)
 
 	this.So(reporter.Errors(), should.Equal, 1)
 	this.So(logger.String(), should.Equal, strings.Join([]string{
-		"[INFO] published article: /a",
-		"[INFO] dropped article",
-		"[INFO] published article: /c",
-		"[WARN] error: GOPHERS",
-		"[INFO] published article: /e",
-		"[INFO] errors encountered:  1",
-		"[INFO] dropped articles:    1",
-		"[INFO] published articles:  3",
-		"[INFO] processing duration: 42ms",
+		`level=INFO msg="published article" path=a.md slug=/a`,
+		`level=WARN msg="article warning" path=a.md slug=/a warning=TERSE`,
+		`level=INFO msg="dropped article" path=b.md slug=/b reason="dropped article"`,
+		`level=INFO msg="published article" path=c.md slug=/c`,
+		`level=ERROR msg="article failed" path=d.md slug=/d handler=MetadataParsingHandler error=GOPHERS`,
+		`level=INFO msg="published article" path=e.md slug=/e`,
+		`level=WARN msg="site warning" warning=LONELY`,
+		`level=INFO msg="stage finished" stage=3 handler=MetadataParsingHandler articles=1 handling=0s throughput="- articles/s" finalizing=0s`,
+		`level=DEBUG msg="slowest articles" stage=3 handler=MetadataParsingHandler articles="d.md (0s)"`,
+		`level=INFO msg="build finished" errors=1 warnings=2 dropped=1 published=3 duration=42ms`,
 		"",
 	}, "\n"))
 }
 
+func (this *ReporterFixture) TestReport() {
+	started := time.Now()
+	stopped := started.Add(time.Millisecond * 42)
+
+	stream := make(chan contracts.Article)
+	go this.load(stream)
+
+	reporter := NewReporter(started, newTestLogger(new(bytes.Buffer)))
+	reporter.ProcessStream(stream)
+	reporter.ProcessWarnings([]error{errors.New("LONELY")})
+
+	this.So(reporter.Report(stopped), should.Equal, BuildReport{
+		Started:   started,
+		Finished:  stopped,
+		Duration:  time.Millisecond * 42,
+		Errors:    1,
+		Warnings:  2,
+		Dropped:   1,
+		Published: 3,
+		Articles: []ArticleReport{
+			{Path: "a.md", Slug: "/a", Outcome: OutcomePublished, Warnings: []string{"TERSE"}},
+			{Path: "b.md", Slug: "/b", Outcome: OutcomeDropped, Error: "dropped article"},
+			{Path: "c.md", Slug: "/c", Outcome: OutcomePublished},
+			{Path: "d.md", Slug: "/d", Outcome: OutcomeError, Error: "GOPHERS"},
+			{Path: "e.md", Slug: "/e", Outcome: OutcomePublished},
+		},
+		SiteWarnings: []string{"LONELY"},
+	})
+}
+
+func (this *ReporterFixture) TestLaterFailuresCountedOncePerArticle() {
+	reporter := NewReporter(time.Now(), newTestLogger(new(bytes.Buffer)))
+	reporter.accountFor(contracts.Article{Source: contracts.ArticleSource{Path: "a.md"}})
+	reporter.accountFor(contracts.Article{Source: contracts.ArticleSource{Path: "b.md"}})
+
+	reporter.fail("a.md", errors.New("ONE"))
+	reporter.fail("a.md", errors.New("TWO"))
+	reporter.fail("", errors.New("SITE"))
+
+	report := reporter.Report(time.Now())
+	this.So(report.Published, should.Equal, 1)
+	this.So(report.Errors, should.Equal, 2)
+	this.So(report.Articles, should.Equal, []ArticleReport{
+		{Path: "a.md", Outcome: OutcomeError, Error: "ONE\nTWO"},
+		{Path: "b.md", Outcome: OutcomePublished},
+		{Outcome: OutcomeError, Error: "SITE"},
+	})
+}
+
+func (this *ReporterFixture) TestLaterWarningsAddedToArticle() {
+	reporter := NewReporter(time.Now(), newTestLogger(new(bytes.Buffer)))
+	reporter.accountFor(contracts.Article{Source: contracts.ArticleSource{Path: "a.md"}})
+	reporter.accountFor(contracts.Article{Source: contracts.ArticleSource{Path: "b.md"}, Error: errors.New("FAILED")})
+
+	reporter.warn("a.md", errors.New("ONE"))
+	reporter.warn("b.md", errors.New("TWO"))
+	reporter.warn("", errors.New("SITE"))
+
+	report := reporter.Report(time.Now())
+	this.So(report.Published, should.Equal, 1)
+	this.So(report.Errors, should.Equal, 1)
+	this.So(report.Warnings, should.Equal, 2)
+	this.So(report.Articles, should.Equal, []ArticleReport{
+		{Path: "a.md", Outcome: OutcomePublished, Warnings: []string{"ONE"}},
+		{Path: "b.md", Outcome: OutcomeError, Error: "FAILED"},
+	})
+	this.So(report.SiteWarnings, should.Equal, []string{"SITE"})
+}
+
 func (this *ReporterFixture) load(stream chan contracts.Article) {
 	defer close(stream)
-	stream <- contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/a"}}
-	stream <- contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/b"}, Error: contracts.ErrDroppedArticle}
-	stream <- contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/c"}}
-	stream <- contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/d"}, Error: errors.New("GOPHERS")}
-	stream <- contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/e"}}
+	stream <- contracts.Article{Source: contracts.ArticleSource{Path: "a.md"}, Metadata: contracts.ArticleMetadata{Slug: "/a"}, Warnings: []error{errors.New("TERSE")}}
+	stream <- contracts.Article{Source: contracts.ArticleSource{Path: "b.md"}, Metadata: contracts.ArticleMetadata{Slug: "/b"}, Error: contracts.ErrDroppedArticle}
+	stream <- contracts.Article{Source: contracts.ArticleSource{Path: "c.md"}, Metadata: contracts.ArticleMetadata{Slug: "/c"}}
+	stream <- contracts.Article{Source: contracts.ArticleSource{Path: "d.md"}, Metadata: contracts.ArticleMetadata{Slug: "/d"}, Error: errors.New("GOPHERS")}
+	stream <- contracts.Article{Source: contracts.ArticleSource{Path: "e.md"}, Metadata: contracts.ArticleMetadata{Slug: "/e"}}
 }
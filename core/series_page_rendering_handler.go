@@ -0,0 +1,57 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// SeriesPageRenderingHandler renders an index page for each series
+// (at /series/<name>/) from the site index, once every article has
+// streamed past.
+type SeriesPageRenderingHandler struct {
+	disk     RenderingFileSystem
+	renderer contracts.Renderer
+	site     *SiteIndex
+	output   string
+}
+
+func NewSeriesPageRenderingHandler(
+	disk RenderingFileSystem,
+	renderer contracts.Renderer,
+	site *SiteIndex,
+	output string,
+) *SeriesPageRenderingHandler {
+	return &SeriesPageRenderingHandler{
+		disk:     disk,
+		renderer: renderer,
+		site:     site,
+		output:   output,
+	}
+}
+
+func (this *SeriesPageRenderingHandler) Handle(*contracts.Article) {}
+
+func (this *SeriesPageRenderingHandler) Finalize() (err error) {
+	for _, name := range this.site.SeriesNames() {
+		series, _ := this.site.Series(name)
+		err = errors.Join(err, this.render(series))
+	}
+	return err
+}
+
+func (this *SeriesPageRenderingHandler) render(series contracts.RenderedSeries) error {
+	rendered, err := this.renderer.Render(series)
+	if err != nil {
+		return err
+	}
+
+	folder := filepath.Join(this.output, "series", series.Name)
+	err = this.disk.MkdirAll(folder, 0755)
+	if err != nil {
+		return err
+	}
+
+	return this.disk.WriteFile(filepath.Join(folder, "index.html"), []byte(rendered), 0644)
+}
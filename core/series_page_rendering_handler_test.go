@@ -0,0 +1,86 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestSeriesPageRenderingHandlerFixture(t *testing.T) {
+	suite.Run(&SeriesPageRenderingHandlerFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type SeriesPageRenderingHandlerFixture struct {
+	*suite.T
+
+	handler  *SeriesPageRenderingHandler
+	renderer *FakeRenderer
+	disk     *FakeFileSystem
+	site     *SiteIndex
+}
+
+func (this *SeriesPageRenderingHandlerFixture) Setup() {
+	this.renderer = NewFakeRenderer()
+	this.disk = NewFakeFileSystem()
+	this.site = NewSiteIndex()
+	this.handler = NewSeriesPageRenderingHandler(this.disk, this.renderer, this.site, "output/folder")
+}
+
+func (this *SeriesPageRenderingHandlerFixture) add(slug, series string, part int) {
+	article := contracts.Article{Metadata: contracts.ArticleMetadata{Slug: slug, Series: series, SeriesPart: part}}
+	this.site.Add(article)
+	this.handler.Handle(&article)
+}
+
+func (this *SeriesPageRenderingHandlerFixture) TestNoSeries_NothingRendered() {
+	this.add("/a", "", 0)
+
+	err := this.handler.Finalize()
+
+	this.So(err, should.BeNil)
+	this.So(this.renderer.all, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
+}
+
+func (this *SeriesPageRenderingHandlerFixture) TestEachSeriesRenderedAndWrittenToDisk() {
+	this.renderer.result = "RENDERED"
+	this.add("/b-2", "b", 2)
+	this.add("/a-1", "a", 1)
+	this.add("/b-1", "b", 1)
+
+	err := this.handler.Finalize()
+
+	this.So(err, should.BeNil)
+	this.So(len(this.renderer.all), should.Equal, 2)
+	this.So(this.renderer.all[0].(contracts.RenderedSeries).Name, should.Equal, "a")
+	this.So(this.renderer.all[1].(contracts.RenderedSeries).Name, should.Equal, "b")
+	this.So(this.disk.Paths(), should.Contain, "output/folder/series/a/index.html")
+	this.So(this.disk.Paths(), should.Contain, "output/folder/series/b/index.html")
+	this.So(this.disk.Content("output/folder/series/b/index.html"), should.Equal, "RENDERED")
+}
+
+func (this *SeriesPageRenderingHandlerFixture) TestRenderErrorReturned() {
+	renderErr := errors.New("boink")
+	this.renderer.err = renderErr
+	this.add("/a-1", "a", 1)
+
+	err := this.handler.Finalize()
+
+	this.So(err, should.WrapError, renderErr)
+	this.So(this.disk.Paths(), should.BeEmpty)
+}
+
+func (this *SeriesPageRenderingHandlerFixture) TestWriteFileErrorReturned() {
+	writeFileErr := errors.New("boink")
+	this.disk.ErrWriteFile["output/folder/series/a/index.html"] = writeFileErr
+	this.add("/a-1", "a", 1)
+	this.add("/b-1", "b", 1)
+
+	err := this.handler.Finalize()
+
+	this.So(err, should.WrapError, writeFileErr)
+	this.So(this.disk.Paths(), should.Contain, "output/folder/series/b/index.html")
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"cmp"
+	"html"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// SiteIndex is a read-only view of every published article, gathered by
+// the pipeline's barrier between preparing and rendering content, so that
+// page renderers can look beyond the article at hand. It is sealed (and
+// sorted) on first read, so read it only once every article has been added.
+// Standalone pages (kind: page) only take part in backlinks.
+type SiteIndex struct {
+	added []contracts.Article
+
+	sealOnce  sync.Once
+	articles  []contracts.RenderedArticleSummary
+	pinned    []contracts.RenderedArticleSummary
+	bySlug    map[string]int
+	pages     map[string]contracts.RenderedArticleSummary
+	backlinks map[string][]contracts.RenderedArticleSummary
+	series    map[string][]contracts.RenderedSeriesPart
+}
+
+func NewSiteIndex() *SiteIndex {
+	return &SiteIndex{}
+}
+
+// Add records the article if it is still headed for publication.
+func (this *SiteIndex) Add(article contracts.Article) {
+	if article.Error != nil {
+		return
+	}
+	this.added = append(this.added, article)
+}
+
+// Articles lists every published article, most recent first.
+func (this *SiteIndex) Articles() []contracts.RenderedArticleSummary {
+	this.sealOnce.Do(this.seal)
+	return slices.Clone(this.articles)
+}
+
+// Pinned lists the published articles declaring pinned: true, lowest
+// weight first (and then most recent first).
+func (this *SiteIndex) Pinned() []contracts.RenderedArticleSummary {
+	this.sealOnce.Do(this.seal)
+	return slices.Clone(this.pinned)
+}
+
+func (this *SiteIndex) find(key string) (contracts.RenderedArticleSummary, bool) {
+	index, found := this.bySlug[key]
+	if !found {
+		page, found := this.pages[key]
+		return page, found
+	}
+	return this.articles[index], true
+}
+
+// Backlinks lists the published articles that link to the article with
+// the given slug, most recent first.
+func (this *SiteIndex) Backlinks(slug string) []contracts.RenderedArticleSummary {
+	this.sealOnce.Do(this.seal)
+	return slices.Clone(this.backlinks[slugKey(slug)])
+}
+
+// Neighbors finds the published articles just before (older) and after
+// (newer) the article with the given slug. When byTopic is set (and the
+// article has any topics), only articles sharing at least one of its
+// topics are considered.
+func (this *SiteIndex) Neighbors(slug string, byTopic bool) (previous, next *contracts.RenderedArticleSummary) {
+	this.sealOnce.Do(this.seal)
+	index, found := this.bySlug[slugKey(slug)]
+	if !found {
+		return nil, nil
+	}
+	topics := this.articles[index].Topics
+	considered := func(candidate contracts.RenderedArticleSummary) bool {
+		return !byTopic || len(topics) == 0 || jaccard(topics, candidate.Topics) > 0
+	}
+	for i := index + 1; i < len(this.articles) && previous == nil; i++ {
+		if considered(this.articles[i]) {
+			previous = &this.articles[i]
+		}
+	}
+	for i := index - 1; i >= 0 && next == nil; i-- {
+		if considered(this.articles[i]) {
+			next = &this.articles[i]
+		}
+	}
+	return clonePointer(previous), clonePointer(next)
+}
+
+// Related ranks the other published articles by how much their topics
+// overlap with those of the article with the given slug (the Jaccard
+// index: shared topics over all topics of either), breaking ties by
+// recency and then by slug, and returns at most count of them. Articles
+// with no topic in common aren't related.
+func (this *SiteIndex) Related(slug string, count int) (related []contracts.RenderedArticleSummary) {
+	this.sealOnce.Do(this.seal)
+	index, found := this.bySlug[slugKey(slug)]
+	if !found || count <= 0 {
+		return nil
+	}
+	topics := this.articles[index].Topics
+	scores := make(map[string]float64)
+	for i, candidate := range this.articles {
+		if i == index {
+			continue
+		}
+		if score := jaccard(topics, candidate.Topics); score > 0 {
+			scores[candidate.Slug] = score
+			related = append(related, candidate)
+		}
+	}
+	// Articles are already sorted by recency (then slug), so a stable sort
+	// by score alone keeps those tie-breakers.
+	slices.SortStableFunc(related, func(i, j contracts.RenderedArticleSummary) int {
+		return cmp.Compare(scores[j.Slug], scores[i.Slug])
+	})
+	return related[:min(count, len(related))]
+}
+
+// jaccard relies on topics being unique within an article (which the
+// metadata parser ensures).
+func jaccard(a, b []string) float64 {
+	shared := 0
+	for _, topic := range a {
+		if slices.Contains(b, topic) {
+			shared++
+		}
+	}
+	if shared == 0 {
+		return 0
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// SeriesNames lists every series with at least one published part.
+func (this *SiteIndex) SeriesNames() []string {
+	this.sealOnce.Do(this.seal)
+	return slices.Sorted(maps.Keys(this.series))
+}
+
+// Series lists the published parts of the named series in order (parts
+// held back as drafts or future articles leave gaps in the numbering).
+func (this *SiteIndex) Series(name string) (contracts.RenderedSeries, bool) {
+	this.sealOnce.Do(this.seal)
+	parts, found := this.series[name]
+	if !found {
+		return contracts.RenderedSeries{}, false
+	}
+	return contracts.RenderedSeries{
+		Name:  name,
+		Slug:  seriesSlug(name),
+		Parts: slices.Clone(parts),
+	}, true
+}
+func seriesSlug(name string) string {
+	return "/series/" + name + "/"
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	clone := *value
+	return &clone
+}
+
+func (this *SiteIndex) seal() {
+	slices.SortFunc(this.added, func(i, j contracts.Article) int {
+		return sortSummaries(summarize(i), summarize(j))
+	})
+	this.bySlug = make(map[string]int, len(this.added))
+	this.pages = make(map[string]contracts.RenderedArticleSummary)
+	for _, article := range this.added {
+		if article.Metadata.IsPage() {
+			this.pages[slugKey(article.Metadata.Slug)] = summarize(article)
+			continue
+		}
+		this.bySlug[slugKey(article.Metadata.Slug)] = len(this.articles)
+		this.articles = append(this.articles, summarize(article))
+	}
+	pinned := slices.DeleteFunc(slices.Clone(this.added), func(article contracts.Article) bool {
+		return !article.Metadata.Pinned || article.Metadata.IsPage()
+	})
+	slices.SortStableFunc(pinned, func(i, j contracts.Article) int {
+		return cmp.Compare(i.Metadata.Weight, j.Metadata.Weight)
+	})
+	for _, article := range pinned {
+		this.pinned = append(this.pinned, summarize(article))
+	}
+	this.backlinks = make(map[string][]contracts.RenderedArticleSummary)
+	this.series = make(map[string][]contracts.RenderedSeriesPart)
+	for _, article := range this.added {
+		summary := summarize(article)
+		for _, target := range this.linkedSlugs(article) {
+			this.backlinks[target] = append(this.backlinks[target], summary)
+		}
+		if name := article.Metadata.Series; name != "" && !article.Metadata.IsPage() {
+			this.series[name] = append(this.series[name], contracts.RenderedSeriesPart{
+				RenderedArticleSummary: summary,
+				Part:                   article.Metadata.SeriesPart,
+			})
+		}
+	}
+	for _, parts := range this.series {
+		slices.SortFunc(parts, func(i, j contracts.RenderedSeriesPart) int {
+			return cmp.Compare(i.Part, j.Part)
+		})
+	}
+}
+
+// linkedSlugs finds the other published articles that the article links to.
+// By now links between articles have been rewritten to their slugs.
+func (this *SiteIndex) linkedSlugs(article contracts.Article) (slugs []string) {
+	for _, match := range hrefAttribute.FindAllStringSubmatch(article.Content.Converted, -1) {
+		parsed, err := url.Parse(html.UnescapeString(match[1]))
+		if err != nil || parsed.Scheme != "" || parsed.Host != "" || !strings.HasPrefix(parsed.Path, "/") {
+			continue
+		}
+		key := slugKey(parsed.Path)
+		if _, found := this.find(key); !found || key == slugKey(article.Metadata.Slug) || slices.Contains(slugs, key) {
+			continue
+		}
+		slugs = append(slugs, key)
+	}
+	return slugs
+}
+
+func summarize(article contracts.Article) contracts.RenderedArticleSummary {
+	return contracts.RenderedArticleSummary{
+		Slug:   article.Metadata.Slug,
+		Title:  article.Metadata.Title,
+		Intro:  article.Metadata.Intro,
+		Date:   article.Metadata.Date,
+		Topics: article.Metadata.Topics,
+		Draft:  article.Metadata.Draft,
+	}
+}
+
+// sortSummaries orders most recent first, then by slug.
+func sortSummaries(i, j contracts.RenderedArticleSummary) int {
+	if order := sortByDateDescending(i, j); order != 0 {
+		return order
+	}
+	return strings.Compare(i.Slug, j.Slug)
+}
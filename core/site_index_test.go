@@ -0,0 +1,166 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestSiteIndexFixture(t *testing.T) {
+	suite.Run(&SiteIndexFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type SiteIndexFixture struct {
+	*suite.T
+
+	site *SiteIndex
+}
+
+func (this *SiteIndexFixture) Setup() {
+	this.site = NewSiteIndex()
+	this.add("/target/", Date(2020, 1, 1), `<a href="/target/#self">self</a>`, nil)
+	this.add("/old/", Date(2020, 1, 2), `<a href="/target/">one</a> <a href="/target/#x">two</a>`, nil)
+	this.add("/new/", Date(2020, 1, 3), `<a href="/target">plain</a> <a href="/nowhere/">gone</a>`, nil)
+	this.add("/same-day/", Date(2020, 1, 3), `<a href="/target/?a=1&amp;b=2">x</a>`, nil)
+	this.add("/a-same-day/", Date(2020, 1, 3), `<a href="/old/">x</a>`, nil)
+	this.add("/draft/", Date(2020, 1, 4), `<a href="/target/">x</a>`, contracts.ErrDroppedArticle)
+	this.add("/external/", Date(2020, 1, 5), `<a href="https://example.com/target/">x</a>`, nil)
+}
+
+func (this *SiteIndexFixture) add(slug string, date time.Time, content string, err error) {
+	this.site.Add(contracts.Article{
+		Error:    err,
+		Metadata: contracts.ArticleMetadata{Slug: slug, Title: slug, Date: date},
+		Content:  contracts.ArticleContent{Converted: content},
+	})
+}
+
+func slugsOf(summaries []contracts.RenderedArticleSummary) (slugs []string) {
+	for _, summary := range summaries {
+		slugs = append(slugs, summary.Slug)
+	}
+	return slugs
+}
+
+func (this *SiteIndexFixture) TestSlugsNormalized() {
+	this.So(slugsOf(this.site.Backlinks("old")), should.Equal, []string{"/a-same-day/"})
+	this.So(slugsOf(this.site.Backlinks("/old")), should.Equal, []string{"/a-same-day/"})
+}
+
+func (this *SiteIndexFixture) TestBacklinks() {
+	this.So(slugsOf(this.site.Backlinks("/target/")), should.Equal, []string{"/new/", "/same-day/", "/old/"})
+	this.So(slugsOf(this.site.Backlinks("/old/")), should.Equal, []string{"/a-same-day/"})
+	this.So(this.site.Backlinks("/new/"), should.BeEmpty)
+}
+
+func (this *SiteIndexFixture) TestResultsAreCopies() {
+	this.site.Backlinks("/target/")[0].Slug = "changed"
+	this.So(this.site.Backlinks("/target/")[0].Slug, should.Equal, "/new/")
+}
+
+func (this *SiteIndexFixture) TestNeighbors() {
+	previous, next := this.site.Neighbors("/new/", false)
+	this.So(previous.Slug, should.Equal, "/same-day/")
+	this.So(next.Slug, should.Equal, "/a-same-day/")
+
+	previous, next = this.site.Neighbors("/target/", false)
+	this.So(previous, should.BeNil)
+	this.So(next.Slug, should.Equal, "/old/")
+
+	previous, next = this.site.Neighbors("/draft/", false)
+	this.So(previous, should.BeNil)
+	this.So(next, should.BeNil)
+}
+
+func (this *SiteIndexFixture) TestNeighborsSharingATopic() {
+	this.site = NewSiteIndex()
+	add := func(slug string, day int, topics ...string) {
+		this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: slug, Date: Date(2020, 1, day), Topics: topics}})
+	}
+	add("/1/", 1, "go")
+	add("/2/", 2, "rust")
+	add("/3/", 3, "rust", "go")
+	add("/4/", 4, "cooking")
+	add("/5/", 5, "rust")
+	add("/6/", 6)
+
+	previous, next := this.site.Neighbors("/3/", true)
+	this.So(previous.Slug, should.Equal, "/2/")
+	this.So(next.Slug, should.Equal, "/5/")
+
+	previous, next = this.site.Neighbors("/1/", true)
+	this.So(previous, should.BeNil)
+	this.So(next.Slug, should.Equal, "/3/")
+
+	previous, next = this.site.Neighbors("/6/", true)
+	this.So(previous.Slug, should.Equal, "/5/")
+	this.So(next, should.BeNil)
+}
+
+func (this *SiteIndexFixture) TestRelated() {
+	this.site = NewSiteIndex()
+	add := func(slug string, day int, topics ...string) {
+		this.site.Add(contracts.Article{Metadata: contracts.ArticleMetadata{Slug: slug, Date: Date(2020, 1, day), Topics: topics}})
+	}
+	add("/subject/", 5, "go", "testing", "tools")
+	add("/half/", 1, "go", "testing")           // 2/3
+	add("/third-old/", 2, "go")                 // 1/3
+	add("/third-new/", 3, "tools")              // 1/3
+	add("/third-new-too/", 3, "testing")        // 1/3
+	add("/same/", 4, "go", "testing", "tools")  // 3/3
+	add("/quarter/", 6, "go", "cooking", "bbq") // 1/5
+	add("/unrelated/", 7, "cooking")            // 0
+
+	this.So(slugsOf(this.site.Related("/subject/", 10)), should.Equal,
+		[]string{"/same/", "/half/", "/third-new-too/", "/third-new/", "/third-old/", "/quarter/"})
+	this.So(slugsOf(this.site.Related("/subject/", 2)), should.Equal, []string{"/same/", "/half/"})
+	this.So(this.site.Related("/subject/", 0), should.BeEmpty)
+	this.So(this.site.Related("/unrelated/", 10), should.Equal, []contracts.RenderedArticleSummary{{Slug: "/quarter/", Date: Date(2020, 1, 6), Topics: []string{"go", "cooking", "bbq"}}})
+}
+
+func (this *SiteIndexFixture) TestSeries() {
+	this.site = NewSiteIndex()
+	add := func(slug, series string, part int, err error) {
+		this.site.Add(contracts.Article{Error: err, Metadata: contracts.ArticleMetadata{
+			Slug: slug, Date: Date(2020, 1, part), Series: series, SeriesPart: part,
+		}})
+	}
+	add("/go-3/", "go", 3, nil)
+	add("/go-1/", "go", 1, nil)
+	add("/go-2/", "go", 2, contracts.ErrDroppedArticle)
+	add("/rust-1/", "rust", 1, nil)
+	add("/standalone/", "", 0, nil)
+
+	this.So(this.site.SeriesNames(), should.Equal, []string{"go", "rust"})
+	series, found := this.site.Series("go")
+	this.So(found, should.BeTrue)
+	this.So(series.Name, should.Equal, "go")
+	this.So(series.Slug, should.Equal, "/series/go/")
+	this.So(len(series.Parts), should.Equal, 2)
+	this.So(series.Parts[0].Part, should.Equal, 1)
+	this.So(series.Parts[0].Slug, should.Equal, "/go-1/")
+	this.So(series.Parts[1].Part, should.Equal, 3)
+	this.So(series.Parts[1].Slug, should.Equal, "/go-3/")
+
+	_, found = this.site.Series("")
+	this.So(found, should.BeFalse)
+}
+
+func (this *SiteIndexFixture) TestPagesOnlyTakePartInBacklinks() {
+	this.site.Add(contracts.Article{
+		Metadata: contracts.ArticleMetadata{Slug: "/about/", Title: "About", Kind: contracts.KindPage},
+		Content:  contracts.ArticleContent{Converted: `<a href="/old/">my first post</a>`},
+	})
+
+	this.add("/contact/", Date(2020, 1, 6), `<a href="/about/">about me</a>`, nil)
+
+	this.So(slugsOf(this.site.Backlinks("/about/")), should.Equal, []string{"/contact/"})
+	this.So(slugsOf(this.site.Backlinks("/old/")), should.Equal, []string{"/a-same-day/", "/about/"})
+	previous, next := this.site.Neighbors("/about/", false)
+	this.So(previous, should.BeNil)
+	this.So(next, should.BeNil)
+	this.So(this.site.Related("/about/", 5), should.BeEmpty)
+}
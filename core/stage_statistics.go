@@ -0,0 +1,113 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// StageStatistics accumulates the time each pipeline stage spends on
+// articles (and in Finalize), keeping track of the slowest articles.
+type StageStatistics struct {
+	lock    sync.Mutex
+	slowest int
+	stages  map[contracts.Stage]*stageStatistic
+}
+
+func NewStageStatistics(slowest int) *StageStatistics {
+	return &StageStatistics{
+		slowest: slowest,
+		stages:  make(map[contracts.Stage]*stageStatistic),
+	}
+}
+
+func (this *StageStatistics) Observe(stage contracts.Stage, article *contracts.Article, started, finished time.Time) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	statistic, found := this.stages[stage]
+	if !found {
+		statistic = &stageStatistic{stage: stage}
+		this.stages[stage] = statistic
+	}
+	elapsed := finished.Sub(started)
+	if article == nil {
+		statistic.finalizing += elapsed
+		return
+	}
+	statistic.articles++
+	statistic.handling += elapsed
+	statistic.slowest = append(statistic.slowest, articleTiming{name: articleName(article), elapsed: elapsed})
+	slices.SortStableFunc(statistic.slowest, func(i, j articleTiming) int { return -cmp.Compare(i.elapsed, j.elapsed) })
+	statistic.slowest = statistic.slowest[:min(len(statistic.slowest), this.slowest)]
+}
+
+// Render logs each stage's timings (in pipeline order). The stage's
+// slowest articles are logged at debug level.
+func (this *StageStatistics) Render(log contracts.Logger) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	stages := slices.SortedFunc(maps.Values(this.stages), func(i, j *stageStatistic) int {
+		return cmp.Compare(i.stage.Index, j.stage.Index)
+	})
+	for _, stage := range stages {
+		log.Info("stage finished",
+			"stage", stage.stage.Index,
+			"handler", stage.stage.Name,
+			"articles", stage.articles,
+			"handling", round(stage.handling),
+			"throughput", stage.throughput(),
+			"finalizing", round(stage.finalizing),
+		)
+		if len(stage.slowest) == 0 {
+			continue
+		}
+		var slowest []string
+		for _, timing := range stage.slowest {
+			slowest = append(slowest, fmt.Sprintf("%s (%s)", timing.name, round(timing.elapsed)))
+		}
+		log.Debug("slowest articles",
+			"stage", stage.stage.Index,
+			"handler", stage.stage.Name,
+			"articles", strings.Join(slowest, ", "),
+		)
+	}
+}
+
+type stageStatistic struct {
+	stage      contracts.Stage
+	articles   int
+	handling   time.Duration
+	finalizing time.Duration
+	slowest    []articleTiming
+}
+
+func (this *stageStatistic) throughput() string {
+	if this.handling <= 0 {
+		return "- articles/s"
+	}
+	return fmt.Sprintf("%.0f articles/s", float64(this.articles)/this.handling.Seconds())
+}
+
+type articleTiming struct {
+	name    string
+	elapsed time.Duration
+}
+
+func articleName(article *contracts.Article) string {
+	if article.Source.Path != "" {
+		return article.Source.Path
+	}
+	return article.Metadata.Slug
+}
+
+func round(duration time.Duration) time.Duration {
+	return duration.Round(time.Microsecond)
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestStageStatisticsFixture(t *testing.T) {
+	suite.Run(&StageStatisticsFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type StageStatisticsFixture struct {
+	*suite.T
+
+	started    time.Time
+	statistics *StageStatistics
+}
+
+func (this *StageStatisticsFixture) Setup() {
+	this.started = Date(2022, 1, 1)
+	this.statistics = NewStageStatistics(2)
+}
+
+func (this *StageStatisticsFixture) observe(stage contracts.Stage, path string, elapsed time.Duration) {
+	var article *contracts.Article
+	if path != "" {
+		article = &contracts.Article{Source: contracts.ArticleSource{Path: path}}
+	}
+	this.statistics.Observe(stage, article, this.started, this.started.Add(elapsed))
+}
+
+func (this *StageStatisticsFixture) TestRenderedInPipelineOrderWithSlowestArticles() {
+	reading := contracts.Stage{Index: 1, Name: "Reading"}
+	rendering := contracts.Stage{Index: 2, Name: "Rendering"}
+	this.observe(rendering, "a.md", 2*time.Millisecond)
+	this.observe(reading, "a.md", 1*time.Millisecond)
+	this.observe(rendering, "b.md", 5*time.Millisecond)
+	this.observe(reading, "b.md", 1*time.Millisecond)
+	this.observe(rendering, "c.md", 3*time.Millisecond)
+	this.observe(rendering, "", 7*time.Millisecond)
+
+	output := new(bytes.Buffer)
+	this.statistics.Render(newTestLogger(output))
+
+	this.So(output.String(), should.Equal, strings.Join([]string{
+		`level=INFO msg="stage finished" stage=1 handler=Reading articles=2 handling=2ms throughput="1000 articles/s" finalizing=0s`,
+		`level=DEBUG msg="slowest articles" stage=1 handler=Reading articles="a.md (1ms), b.md (1ms)"`,
+		`level=INFO msg="stage finished" stage=2 handler=Rendering articles=3 handling=10ms throughput="300 articles/s" finalizing=7ms`,
+		`level=DEBUG msg="slowest articles" stage=2 handler=Rendering articles="b.md (5ms), c.md (3ms)"`,
+		"",
+	}, "\n"))
+}
+
+func (this *StageStatisticsFixture) TestNothingObserved_NothingRendered() {
+	output := new(bytes.Buffer)
+	this.statistics.Render(newTestLogger(output))
+	this.So(output.String(), should.BeEmpty)
+}
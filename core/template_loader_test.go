@@ -16,18 +16,18 @@ func TestTemplateLoaderFixture(t *testing.T) {
 type TemplateLoaderFixture struct {
 	*suite.T
 
-	disk   *InMemoryFileSystem
+	disk   *FakeFileSystem
 	loader *TemplateLoader
 }
 
 func (this *TemplateLoaderFixture) Setup() {
-	this.disk = NewInMemoryFileSystem()
+	this.disk = NewFakeFileSystem()
 	this.loader = NewTemplateLoader(this.disk, "templates")
 	_ = this.disk.MkdirAll("templates", 0755)
-	_ = this.disk.WriteFile("templates/supplemental-template.tmpl", []byte(""), 0644)
-	_ = this.disk.WriteFile("templates/"+contracts.HomePageTemplateName, []byte(ValidHomePageTemplate), 0644)
-	_ = this.disk.WriteFile("templates/"+contracts.TopicsTemplateName, []byte(ValidTopicsPageTemplate), 0644)
-	_ = this.disk.WriteFile("templates/"+contracts.ArticleTemplateName, []byte(ValidArticlePageTemplate), 0644)
+	this.disk.Write("templates/supplemental-template.tmpl", "")
+	this.disk.Write("templates/"+contracts.HomePageTemplateName, ValidHomePageTemplate)
+	this.disk.Write("templates/"+contracts.TopicsTemplateName, ValidTopicsPageTemplate)
+	this.disk.Write("templates/"+contracts.ArticleTemplateName, ValidArticlePageTemplate)
 }
 
 func (this *TemplateLoaderFixture) TestLoadsEachTemplate() {
@@ -41,7 +41,7 @@ func (this *TemplateLoaderFixture) TestLoadsEachTemplate() {
 }
 
 func (this *TemplateLoaderFixture) TestNonTemplateFilesIgnored() {
-	_ = this.disk.WriteFile("templates/not-a-template", []byte(ValidHomePageTemplate), 0644)
+	this.disk.Write("templates/not-a-template", ValidHomePageTemplate)
 
 	templates, err := this.loader.Load()
 
@@ -53,7 +53,7 @@ func (this *TemplateLoaderFixture) TestNonTemplateFilesIgnored() {
 }
 
 func (this *TemplateLoaderFixture) TestInvalidTemplateFiles_Error() {
-	_ = this.disk.WriteFile("templates/invalid-template.tmpl", []byte("{{ .invalid {{{}{{{})"), 0644)
+	this.disk.Write("templates/invalid-template.tmpl", "{{ .invalid {{{}{{{})")
 
 	templates, err := this.loader.Load()
 
@@ -83,8 +83,8 @@ func (this *TemplateLoaderFixture) TestReadFileErr() {
 
 func (this *TemplateLoaderFixture) TestSubdirectoryTemplatesNamespaced() {
 	_ = this.disk.MkdirAll("templates/components", 0755)
-	_ = this.disk.WriteFile("templates/components/header.tmpl", []byte("<header>COMPONENT</header>"), 0644)
-	_ = this.disk.WriteFile("templates/components/footer.tmpl", []byte("<footer>COMPONENT</footer>"), 0644)
+	this.disk.Write("templates/components/header.tmpl", "<header>COMPONENT</header>")
+	this.disk.Write("templates/components/footer.tmpl", "<footer>COMPONENT</footer>")
 
 	templates, err := this.loader.Load()
 
@@ -95,8 +95,8 @@ func (this *TemplateLoaderFixture) TestSubdirectoryTemplatesNamespaced() {
 }
 
 func (this *TemplateLoaderFixture) TestLoadedTemplatesEscapeArticleMetadata() {
-	_ = this.disk.WriteFile("templates/"+contracts.ArticleTemplateName, []byte(
-		`<title>{{ .Title }}</title><meta name="description" content="{{ .Intro }}"><a href="{{ .Slug }}">Article</a>`), 0644)
+	this.disk.Write("templates/"+contracts.ArticleTemplateName,
+		`<title>{{ .Title }}</title><meta name="description" content="{{ .Intro }}"><a href="{{ .Slug }}">Article</a>`)
 
 	templates, err := this.loader.Load()
 	this.So(err, should.BeNil)
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"html/template"
 	"reflect"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
 
 	"github.com/mdw-tools/hugoinho/contracts"
 )
@@ -25,6 +28,17 @@ func (this *TemplateRenderer) Validate() (result error) {
 		contracts.RenderedTopicsListing{},
 		contracts.RenderedArticle{},
 	}
+	// The series template is only needed by sites that have a series, and
+	// the page and not-found templates are optional.
+	if this.HasTemplate(contracts.SeriesTemplateName) {
+		pages = append(pages, contracts.RenderedSeries{})
+	}
+	if this.HasTemplate(contracts.PageTemplateName) {
+		pages = append(pages, contracts.RenderedPage{})
+	}
+	if this.HasTemplate(contracts.NotFoundTemplateName) {
+		pages = append(pages, contracts.RenderedNotFoundPage{})
+	}
 	for _, page := range pages {
 		if _, err := this.Render(page); err != nil {
 			result = errors.Join(result, err)
@@ -42,6 +56,18 @@ func (this *TemplateRenderer) Render(v any) (string, error) {
 			Content:         template.HTML(instance.Content),
 		})
 
+	case contracts.RenderedPage:
+		return this.render(this.firstTemplate(contracts.PageTemplateName), renderedArticle{
+			RenderedArticle: instance.RenderedArticle,
+			Content:         template.HTML(instance.Content),
+		})
+
+	case contracts.RenderedNotFoundPage:
+		return this.render(this.firstTemplate(contracts.NotFoundTemplateName, contracts.PageTemplateName), renderedArticle{
+			RenderedArticle: instance.RenderedArticle,
+			Content:         template.HTML(instance.Content),
+		})
+
 	case contracts.RenderedArchivesPage:
 		return this.render(contracts.ArchivesTemplateName, v)
 
@@ -51,6 +77,9 @@ func (this *TemplateRenderer) Render(v any) (string, error) {
 	case contracts.RenderedHomePage:
 		return this.render(contracts.HomePageTemplateName, v)
 
+	case contracts.RenderedSeries:
+		return this.render(contracts.SeriesTemplateName, v)
+
 	default:
 		return "", fmt.Errorf(
 			"%w [%v]: %v",
@@ -60,19 +89,74 @@ func (this *TemplateRenderer) Render(v any) (string, error) {
 	}
 }
 
+func (this *TemplateRenderer) HasTemplate(name string) bool {
+	return this.templates.Lookup(name) != nil
+}
+
+// firstTemplate picks the first of the optional templates that exists,
+// falling back to the article template.
+func (this *TemplateRenderer) firstTemplate(optional ...string) string {
+	for _, name := range optional {
+		if this.HasTemplate(name) {
+			return name
+		}
+	}
+	return contracts.ArticleTemplateName
+}
+
 func (this *TemplateRenderer) render(name string, data any) (string, error) {
 	buffer := new(bytes.Buffer)
 	err := this.templates.ExecuteTemplate(buffer, name, data)
 	if err != nil {
-		return "", fmt.Errorf(
-			"%w failed to render template [%s] (err: %v) with data of type [%v]: %+v",
-			contracts.ErrRenderingFailure,
-			name, err, reflect.TypeOf(data), data,
-		)
+		return "", fmt.Errorf("%w: %w", contracts.ErrRenderingFailure, describeTemplateError(name, err))
 	}
 	return buffer.String(), nil
 }
 
+// describeTemplateError condenses an error from the template package into
+// the template name, position and failing field (leaving out the data being
+// rendered, which can be huge), while still wrapping the original error.
+func describeTemplateError(name string, err error) error {
+	var escape *template.Error
+	if errors.As(err, &escape) {
+		description := fmt.Sprintf("template [%s]", escape.Name)
+		if escape.Line > 0 {
+			description += fmt.Sprintf(" line %d", escape.Line)
+		}
+		return templateError{description: description + ": " + escape.Description, err: err}
+	}
+	var exec texttemplate.ExecError
+	if !errors.As(err, &exec) {
+		return templateError{description: fmt.Sprintf("template [%s]: %s", name, strings.TrimPrefix(err.Error(), "template: ")), err: err}
+	}
+	// The position and field are only spelled out in the message, after the name.
+	message := strings.TrimPrefix(exec.Err.Error(), "template: "+exec.Name+":")
+	match := templateExecPosition.FindStringSubmatch(message)
+	if match == nil {
+		return templateError{description: fmt.Sprintf("template [%s]: %s", exec.Name, message), err: err}
+	}
+	description := fmt.Sprintf("template [%s] line %s", exec.Name, match[1])
+	if match[2] != "" {
+		description += ", column " + match[2]
+	}
+	if match[3] != "" {
+		description += ", at " + match[3]
+	}
+	return templateError{description: description + ": " + match[4], err: err}
+}
+
+var templateExecPosition = regexp.MustCompile(`^(\d+):(?:(\d+):)? (?:executing "[^"]*" at (<[^>]*>): )?(.*)$`)
+
+// templateError describes a template error compactly but unwraps to the
+// original, so callers can still reach the text/template ExecError.
+type templateError struct {
+	description string
+	err         error
+}
+
+func (this templateError) Error() string { return this.description }
+func (this templateError) Unwrap() error { return this.err }
+
 // renderedArticle is an internal representation of the article with the Content
 // (emitted from Markdown converter) marked as safe HTML.
 type renderedArticle struct {
@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"html/template"
 	"testing"
+	texttemplate "text/template"
 
 	"github.com/mdw-go/testing/v2/should"
 	"github.com/mdw-go/testing/v2/suite"
@@ -75,7 +77,65 @@ func (this *TemplateRendererFixture) TestMissingArchivesTemplate_ValidateErr() {
 	this.So(this.renderer.Validate(), should.NOT.BeNil)
 }
 
+func (this *TemplateRendererFixture) TestSeriesTemplateValidatedOnlyWhenPresent() {
+	this.So(this.renderer.Validate(), should.BeNil)
+
+	this.parseRequiredTemplates()
+	var err error
+	this.templates, err = this.templates.New(contracts.SeriesTemplateName).Parse("{{ .UnknownField }}")
+	this.So(err, should.BeNil)
+	this.renderer = NewTemplateRenderer(this.templates)
+	this.So(this.renderer.Validate(), should.WrapError, contracts.ErrRenderingFailure)
+}
+func (this *TemplateRendererFixture) parseRequiredTemplates() {
+	this.templates = nil
+	this.parseTemplate(contracts.HomePageTemplateName)
+	this.parseTemplate(contracts.ArchivesTemplateName)
+	this.parseTemplate(contracts.ArticleTemplateName)
+	this.parseTemplate(contracts.TopicsTemplateName)
+}
+
+func (this *TemplateRendererFixture) TestPagesFallBackToArticleTemplate() {
+	rendered, err := this.renderer.Render(contracts.RenderedPage{})
+	this.So(err, should.BeNil)
+	this.So(rendered, should.Equal, contracts.ArticleTemplateName)
+
+	this.parseRequiredTemplates()
+	this.parseTemplate(contracts.PageTemplateName)
+	this.renderer = NewTemplateRenderer(this.templates)
+	this.So(this.renderer.Validate(), should.BeNil)
+	rendered, err = this.renderer.Render(contracts.RenderedPage{})
+	this.So(err, should.BeNil)
+	this.So(rendered, should.Equal, contracts.PageTemplateName)
+}
+
+func (this *TemplateRendererFixture) TestNotFoundPageFallsBackToPageThenArticleTemplate() {
+	rendered, err := this.renderer.Render(contracts.RenderedNotFoundPage{})
+	this.So(err, should.BeNil)
+	this.So(rendered, should.Equal, contracts.ArticleTemplateName)
+	this.So(this.renderer.HasTemplate(contracts.NotFoundTemplateName), should.BeFalse)
+
+	this.parseRequiredTemplates()
+	this.parseTemplate(contracts.PageTemplateName)
+	this.renderer = NewTemplateRenderer(this.templates)
+	rendered, _ = this.renderer.Render(contracts.RenderedNotFoundPage{})
+	this.So(rendered, should.Equal, contracts.PageTemplateName)
+
+	this.parseRequiredTemplates()
+	this.parseTemplate(contracts.PageTemplateName)
+	this.parseTemplate(contracts.NotFoundTemplateName)
+	this.renderer = NewTemplateRenderer(this.templates)
+	this.So(this.renderer.Validate(), should.BeNil)
+	this.So(this.renderer.HasTemplate(contracts.NotFoundTemplateName), should.BeTrue)
+	rendered, _ = this.renderer.Render(contracts.RenderedNotFoundPage{})
+	this.So(rendered, should.Equal, contracts.NotFoundTemplateName)
+}
+
 func (this *TemplateRendererFixture) TestCanRenderTypesCorrespondingToTemplates() {
+	this.parseRequiredTemplates()
+	this.parseTemplate(contracts.SeriesTemplateName)
+	this.renderer = NewTemplateRenderer(this.templates)
+
 	home, homeErr := this.renderer.Render(contracts.RenderedHomePage{})
 	this.So(homeErr, should.BeNil)
 	this.So(home, should.Equal, contracts.HomePageTemplateName)
@@ -91,6 +151,10 @@ func (this *TemplateRendererFixture) TestCanRenderTypesCorrespondingToTemplates(
 	topics, topicsErr := this.renderer.Render(contracts.RenderedTopicsListing{})
 	this.So(topicsErr, should.BeNil)
 	this.So(topics, should.Equal, contracts.TopicsTemplateName)
+
+	series, seriesErr := this.renderer.Render(contracts.RenderedSeries{})
+	this.So(seriesErr, should.BeNil)
+	this.So(series, should.Equal, contracts.SeriesTemplateName)
 }
 
 func (this *TemplateRendererFixture) TestCannotRenderUnknownTypes() {
@@ -115,3 +179,35 @@ func (this *TemplateRendererFixture) prepareRendererWithBadTemplate() {
 
 	this.renderer = NewTemplateRenderer(t)
 }
+
+func (this *TemplateRendererFixture) TestRenderErrorIsCompact() {
+	this.prepareRendererWithBadTemplate()
+
+	_, err := this.renderer.Render(contracts.RenderedTopicsListing{Topics: []contracts.RenderedTopicListing{{Topic: "a-very-long-topic-name"}}})
+
+	this.So(err.Error(), should.Equal, contracts.ErrRenderingFailure.Error()+": "+
+		"template [topics.tmpl] line 1, column 3, at <.UnknownField>: "+
+		"can't evaluate field UnknownField in type contracts.RenderedTopicsListing")
+}
+
+func (this *TemplateRendererFixture) TestRenderErrorWrapsTemplateError() {
+	this.prepareRendererWithBadTemplate()
+
+	_, err := this.renderer.Render(contracts.RenderedTopicsListing{})
+
+	var exec texttemplate.ExecError
+	this.So(errors.As(err, &exec), should.BeTrue)
+	this.So(exec.Name, should.Equal, contracts.TopicsTemplateName)
+}
+
+func (this *TemplateRendererFixture) TestEscapingErrorIsCompact() {
+	t, err := template.New(contracts.TopicsTemplateName).Parse(`<a href="{{ .Topics }}`)
+	this.So(err, should.BeNil)
+	this.renderer = NewTemplateRenderer(t)
+
+	_, err = this.renderer.Render(contracts.RenderedTopicsListing{})
+
+	var escape *template.Error
+	this.So(errors.As(err, &escape), should.BeTrue)
+	this.So(err.Error(), should.StartWith, contracts.ErrRenderingFailure.Error()+": template [topics.tmpl]: ends in a non-text context")
+}
@@ -1,47 +1,53 @@
 package core
 
 import (
+	"fmt"
+	"maps"
 	"path/filepath"
 	"slices"
-	"sort"
+	"strings"
 
 	"github.com/mdw-tools/hugoinho/contracts"
 )
 
+// TopicPageRenderingHandler lists, for each topic mentioned by at least
+// the configured minimum number of articles, those articles (newest first),
+// from the site index once every article has streamed past.
 type TopicPageRenderingHandler struct {
 	disk     RenderingFileSystem
 	renderer contracts.Renderer
+	site     *SiteIndex
+	settings TopicSettings
 	output   string
-	topics   map[string][]contracts.RenderedArticleSummary
 }
 
 func NewTopicPageRenderingHandler(
 	disk RenderingFileSystem,
 	renderer contracts.Renderer,
+	site *SiteIndex,
+	settings TopicSettings,
 	output string,
 ) *TopicPageRenderingHandler {
 	return &TopicPageRenderingHandler{
 		disk:     disk,
 		renderer: renderer,
+		site:     site,
+		settings: settings,
 		output:   output,
-		topics:   make(map[string][]contracts.RenderedArticleSummary),
 	}
 }
 
-func (this *TopicPageRenderingHandler) Handle(article *contracts.Article) {
-	seen := make(map[string]bool)
-	for _, topic := range article.Metadata.Topics {
-		if seen[topic] {
-			continue
+func (this *TopicPageRenderingHandler) Handle(*contracts.Article) {}
+
+// topics lists the articles (newest first) mentioning each topic.
+func (this *TopicPageRenderingHandler) topics() map[string][]contracts.RenderedArticleSummary {
+	topics := make(map[string][]contracts.RenderedArticleSummary)
+	for _, article := range this.site.Articles() {
+		for _, topic := range slices.Compact(slices.Sorted(slices.Values(article.Topics))) {
+			topics[topic] = append(topics[topic], article)
 		}
-		seen[topic] = true
-		this.topics[topic] = append(this.topics[topic], contracts.RenderedArticleSummary{
-			Slug:  article.Metadata.Slug,
-			Title: article.Metadata.Title,
-			Intro: article.Metadata.Intro,
-			Date:  article.Metadata.Date,
-		})
 	}
+	return topics
 }
 
 func (this *TopicPageRenderingHandler) Finalize() error {
@@ -64,27 +70,60 @@ func (this *TopicPageRenderingHandler) Finalize() error {
 	return nil
 }
 
+// Warnings lists the topics left off the topics page because too few
+// articles mention them.
+func (this *TopicPageRenderingHandler) Warnings() (warnings []error) {
+	topics := this.topics()
+	for _, topic := range slices.Sorted(maps.Keys(topics)) {
+		articles := topics[topic]
+		if len(articles) < this.settings.Minimum {
+			var slugs []string
+			for _, article := range articles {
+				slugs = append(slugs, article.Slug)
+			}
+			warnings = append(warnings, fmt.Errorf("%w: [%s] (only %s; the minimum is %d)",
+				errLonelyTopic, topic, strings.Join(slugs, ", "), this.settings.Minimum))
+		}
+	}
+	return warnings
+}
+
 func (this *TopicPageRenderingHandler) prepareRendering() (full contracts.RenderedTopicsListing) {
-	for _, topic := range this.sortTopics() {
-		articles := this.topics[topic]
-		sort.Slice(articles, func(i, j int) bool {
-			return articles[i].Date.After(articles[j].Date)
-		})
+	topics := this.topics()
+	counts := make(leaderboard[string])
+	for topic, articles := range topics {
+		if len(articles) >= this.settings.Minimum {
+			counts[topic] = len(articles)
+		}
+	}
+	for _, topic := range this.settings.sort(counts, slices.Collect(maps.Keys(counts))) {
 		full.Topics = append(full.Topics, contracts.RenderedTopicListing{
 			Topic:    topic,
-			Articles: articles,
+			Count:    len(topics[topic]),
+			Articles: topics[topic],
 		})
 	}
 	return full
 }
 
-func (this *TopicPageRenderingHandler) sortTopics() (topics []string) {
-	for topic := range this.topics {
-		if len(this.topics[topic]) == 1 {
-			continue
-		}
-		topics = append(topics, topic)
+// TopicSettings decide which topics are listed (those mentioned by at least
+// Minimum articles) and in which Order (TopicOrderAlphabetical or
+// TopicOrderPopularity).
+type TopicSettings struct {
+	Minimum int
+	Order   string
+}
+
+const (
+	TopicOrderAlphabetical = "alphabetical"
+	TopicOrderPopularity   = "popularity"
+)
+
+// sort orders the topics, the most mentioned first (see leaderboard) if by
+// popularity.
+func (this TopicSettings) sort(counts leaderboard[string], topics []string) []string {
+	if this.Order == TopicOrderPopularity {
+		return slices.SortedStableFunc(slices.Values(topics), counts.compare)
 	}
-	slices.Sort(topics)
-	return topics
+	return slices.Sorted(slices.Values(topics))
 }
@@ -18,19 +18,31 @@ type TopicPageRenderingHandlerFixture struct {
 	*suite.T
 
 	handler  *TopicPageRenderingHandler
-	disk     *InMemoryFileSystem
+	site     *SiteIndex
+	disk     *FakeFileSystem
 	renderer *FakeRenderer
+	settings TopicSettings
 }
 
 func (this *TopicPageRenderingHandlerFixture) Setup() {
-	this.disk = NewInMemoryFileSystem()
+	this.disk = NewFakeFileSystem()
 	this.renderer = NewFakeRenderer()
-	this.handler = NewTopicPageRenderingHandler(this.disk, this.renderer, "output/folder")
+	this.settings = TopicSettings{Minimum: 2, Order: TopicOrderAlphabetical}
+	this.reset(this.settings)
 	this.handleArticles()
 }
 
+func (this *TopicPageRenderingHandlerFixture) reset(settings TopicSettings) {
+	this.site = NewSiteIndex()
+	this.handler = NewTopicPageRenderingHandler(this.disk, this.renderer, this.site, settings, "output/folder")
+}
+func (this *TopicPageRenderingHandlerFixture) add(article *contracts.Article) {
+	this.site.Add(*article)
+	this.handler.Handle(article)
+}
+
 func (this *TopicPageRenderingHandlerFixture) handleArticles() {
-	this.handler.Handle(&contracts.Article{
+	this.add(&contracts.Article{
 		Metadata: contracts.ArticleMetadata{
 			Slug:   "/slug1",
 			Title:  "title1",
@@ -39,7 +51,7 @@ func (this *TopicPageRenderingHandlerFixture) handleArticles() {
 			Topics: []string{"a", "b"},
 		},
 	})
-	this.handler.Handle(&contracts.Article{
+	this.add(&contracts.Article{
 		Metadata: contracts.ArticleMetadata{
 			Slug:   "/slug2",
 			Title:  "title2",
@@ -48,7 +60,7 @@ func (this *TopicPageRenderingHandlerFixture) handleArticles() {
 			Topics: []string{"b", "c"},
 		},
 	})
-	this.handler.Handle(&contracts.Article{
+	this.add(&contracts.Article{
 		Metadata: contracts.ArticleMetadata{
 			Slug:   "/slug3",
 			Title:  "title3",
@@ -57,6 +69,14 @@ func (this *TopicPageRenderingHandlerFixture) handleArticles() {
 			Topics: []string{"c"},
 		},
 	})
+	this.add(&contracts.Article{ // pages are left out
+		Metadata: contracts.ArticleMetadata{
+			Slug:   "/page",
+			Title:  "page",
+			Kind:   contracts.KindPage,
+			Topics: []string{"a"},
+		},
+	})
 }
 
 func (this *TopicPageRenderingHandlerFixture) assertHandledArticlesRendered() {
@@ -64,35 +84,41 @@ func (this *TopicPageRenderingHandlerFixture) assertHandledArticlesRendered() {
 		Topics: []contracts.RenderedTopicListing{
 			{
 				Topic: "b",
+				Count: 2,
 				Articles: []contracts.RenderedArticleSummary{
 					{
-						Slug:  "/slug2",
-						Title: "title2",
-						Intro: "intro2",
-						Date:  Date(2020, 2, 2),
+						Slug:   "/slug2",
+						Title:  "title2",
+						Intro:  "intro2",
+						Date:   Date(2020, 2, 2),
+						Topics: []string{"b", "c"},
 					},
 					{
-						Slug:  "/slug1",
-						Title: "title1",
-						Intro: "intro1",
-						Date:  Date(2020, 1, 1),
+						Slug:   "/slug1",
+						Title:  "title1",
+						Intro:  "intro1",
+						Date:   Date(2020, 1, 1),
+						Topics: []string{"a", "b"},
 					},
 				},
 			},
 			{
 				Topic: "c",
+				Count: 2,
 				Articles: []contracts.RenderedArticleSummary{
 					{
-						Slug:  "/slug3",
-						Title: "title3",
-						Intro: "intro3",
-						Date:  Date(2020, 3, 3),
+						Slug:   "/slug3",
+						Title:  "title3",
+						Intro:  "intro3",
+						Date:   Date(2020, 3, 3),
+						Topics: []string{"c"},
 					},
 					{
-						Slug:  "/slug2",
-						Title: "title2",
-						Intro: "intro2",
-						Date:  Date(2020, 2, 2),
+						Slug:   "/slug2",
+						Title:  "title2",
+						Intro:  "intro2",
+						Date:   Date(2020, 2, 2),
+						Topics: []string{"b", "c"},
 					},
 				},
 			},
@@ -108,10 +134,9 @@ func (this *TopicPageRenderingHandlerFixture) TestFileTemplateRenderedAndWritten
 
 	this.So(err, should.BeNil)
 	this.assertHandledArticlesRendered()
-	this.So(this.disk.Files, should.Contain, "output/folder")
-	this.So(this.disk.Files, better.Contain, "output/folder/topics/index.html")
-	file := this.disk.Files["output/folder/topics/index.html"]
-	this.So(file.Content(), should.Equal, "RENDERED")
+	this.So(this.disk.Paths(), should.Contain, "output/folder")
+	this.So(this.disk.Paths(), better.Contain, "output/folder/topics/index.html")
+	this.So(this.disk.Content("output/folder/topics/index.html"), should.Equal, "RENDERED")
 }
 
 func (this *TopicPageRenderingHandlerFixture) TestRenderErrorReturned() {
@@ -121,7 +146,7 @@ func (this *TopicPageRenderingHandlerFixture) TestRenderErrorReturned() {
 	err := this.handler.Finalize()
 
 	this.So(err, should.WrapError, renderErr)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 
 func (this *TopicPageRenderingHandlerFixture) TestMkdirAllErrorReturned() {
@@ -132,7 +157,7 @@ func (this *TopicPageRenderingHandlerFixture) TestMkdirAllErrorReturned() {
 	err := this.handler.Finalize()
 
 	this.So(err, should.WrapError, mkdirErr)
-	this.So(this.disk.Files, should.BeEmpty)
+	this.So(this.disk.Paths(), should.BeEmpty)
 }
 
 func (this *TopicPageRenderingHandlerFixture) TestWriteFileErrorReturned() {
@@ -143,12 +168,12 @@ func (this *TopicPageRenderingHandlerFixture) TestWriteFileErrorReturned() {
 	err := this.handler.Finalize()
 
 	this.So(err, should.WrapError, writeFileErr)
-	this.So(this.disk.Files, should.NOT.Contain, "output/folder/topics/index.html")
+	this.So(this.disk.Paths(), should.NOT.Contain, "output/folder/topics/index.html")
 }
 
 func (this *TopicPageRenderingHandlerFixture) TestDuplicateTopicsDeduplicated() {
-	this.handler = NewTopicPageRenderingHandler(this.disk, this.renderer, "output/folder")
-	this.handler.Handle(&contracts.Article{
+	this.reset(this.settings)
+	this.add(&contracts.Article{
 		Metadata: contracts.ArticleMetadata{
 			Slug:   "/slug1",
 			Title:  "title1",
@@ -157,7 +182,7 @@ func (this *TopicPageRenderingHandlerFixture) TestDuplicateTopicsDeduplicated()
 			Topics: []string{"a", "a", "a"},
 		},
 	})
-	this.handler.Handle(&contracts.Article{
+	this.add(&contracts.Article{
 		Metadata: contracts.ArticleMetadata{
 			Slug:   "/slug2",
 			Title:  "title2",
@@ -174,21 +199,78 @@ func (this *TopicPageRenderingHandlerFixture) TestDuplicateTopicsDeduplicated()
 		Topics: []contracts.RenderedTopicListing{
 			{
 				Topic: "a",
+				Count: 2,
 				Articles: []contracts.RenderedArticleSummary{
 					{
-						Slug:  "/slug2",
-						Title: "title2",
-						Intro: "intro2",
-						Date:  Date(2020, 2, 2),
+						Slug:   "/slug2",
+						Title:  "title2",
+						Intro:  "intro2",
+						Date:   Date(2020, 2, 2),
+						Topics: []string{"a", "b", "a"},
 					},
 					{
-						Slug:  "/slug1",
-						Title: "title1",
-						Intro: "intro1",
-						Date:  Date(2020, 1, 1),
+						Slug:   "/slug1",
+						Title:  "title1",
+						Intro:  "intro1",
+						Date:   Date(2020, 1, 1),
+						Topics: []string{"a", "a", "a"},
 					},
 				},
 			},
 		},
 	})
 }
+
+func (this *TopicPageRenderingHandlerFixture) TestLonelyTopicsWarned() {
+	warnings := this.handler.Warnings()
+
+	this.So(len(warnings), should.Equal, 1)
+	this.So(warnings[0], should.WrapError, errLonelyTopic)
+	this.So(warnings[0].Error(), should.EndWith, ": [a] (only /slug1; the minimum is 2)")
+}
+
+func (this *TopicPageRenderingHandlerFixture) topicsRendered() (topics []string, counts []int) {
+	for _, listing := range this.renderer.rendered.(contracts.RenderedTopicsListing).Topics {
+		topics = append(topics, listing.Topic)
+		counts = append(counts, listing.Count)
+	}
+	return topics, counts
+}
+
+func (this *TopicPageRenderingHandlerFixture) TestMinimumConfigurable() {
+	this.settings.Minimum = 1
+	this.reset(this.settings)
+	this.handleArticles()
+
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	topics, counts := this.topicsRendered()
+	this.So(topics, should.Equal, []string{"a", "b", "c"})
+	this.So(counts, should.Equal, []int{1, 2, 2})
+	this.So(this.handler.Warnings(), should.BeEmpty)
+}
+
+func (this *TopicPageRenderingHandlerFixture) TestHigherMinimumWarnsOfEachTopicBelowIt() {
+	this.reset(TopicSettings{Minimum: 3})
+	this.handleArticles()
+
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	topics, _ := this.topicsRendered()
+	this.So(topics, should.BeEmpty)
+	warnings := this.handler.Warnings()
+	this.So(len(warnings), should.Equal, 3)
+	this.So(warnings[1].Error(), should.EndWith, ": [b] (only /slug2, /slug1; the minimum is 3)")
+}
+
+func (this *TopicPageRenderingHandlerFixture) TestOrderedByPopularity() {
+	this.reset(TopicSettings{Minimum: 1, Order: TopicOrderPopularity})
+	this.handleArticles()
+	this.add(&contracts.Article{Metadata: contracts.ArticleMetadata{Slug: "/slug4", Topics: []string{"c"}}})
+
+	this.So(this.handler.Finalize(), should.BeNil)
+
+	topics, counts := this.topicsRendered()
+	this.So(topics, should.Equal, []string{"c", "b", "a"})
+	this.So(counts, should.Equal, []int{3, 2, 1})
+}
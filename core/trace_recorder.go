@@ -0,0 +1,101 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// TraceRecorder observes the pipeline and records begin/end events in the
+// Chrome Trace Event format (see chrome://tracing or ui.perfetto.dev).
+// Each stage gets its own lane (tid) so that queueing between stages is
+// visible as gaps.
+type TraceRecorder struct {
+	lock   sync.Mutex
+	origin time.Time
+	stages map[contracts.Stage]struct{}
+	events []traceEvent
+}
+
+func NewTraceRecorder(origin time.Time) *TraceRecorder {
+	return &TraceRecorder{
+		origin: origin,
+		stages: make(map[contracts.Stage]struct{}),
+	}
+}
+
+func (this *TraceRecorder) Observe(stage contracts.Stage, article *contracts.Article, started, finished time.Time) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if _, found := this.stages[stage]; !found {
+		this.stages[stage] = struct{}{}
+		this.events = append(this.events, traceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			PID:   1,
+			TID:   stage.Index,
+			Args:  map[string]string{"name": stage.Name},
+		})
+	}
+
+	name, args := "Finalize", map[string]string(nil)
+	if article != nil {
+		name, args = articleName(article), map[string]string{"slug": article.Metadata.Slug}
+		if article.Error != nil {
+			args["error"] = article.Error.Error()
+		}
+	}
+	this.events = append(this.events,
+		traceEvent{Name: name, Category: stage.Name, Phase: "B", Timestamp: this.micros(started), PID: 1, TID: stage.Index, Args: args},
+		traceEvent{Name: name, Category: stage.Name, Phase: "E", Timestamp: this.micros(finished), PID: 1, TID: stage.Index},
+	)
+}
+
+func (this *TraceRecorder) micros(instant time.Time) float64 {
+	return float64(instant.Sub(this.origin).Nanoseconds()) / 1000
+}
+
+func (this *TraceRecorder) JSON() ([]byte, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	events := this.events
+	if events == nil {
+		events = []traceEvent{}
+	}
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(traceDocument{Events: events, DisplayTimeUnit: "ms"})
+	return buffer.Bytes(), err
+}
+
+type traceDocument struct {
+	Events          []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+type traceEvent struct {
+	Name      string            `json:"name"`
+	Category  string            `json:"cat,omitempty"`
+	Phase     string            `json:"ph"`
+	Timestamp float64           `json:"ts"`
+	PID       int               `json:"pid"`
+	TID       int               `json:"tid"`
+	Args      map[string]string `json:"args,omitempty"`
+}
+
+///////////////////////////////////////////////////////////////////
+
+// Observers fans each observation out to several observers.
+type Observers []contracts.Observer
+
+func (this Observers) Observe(stage contracts.Stage, article *contracts.Article, started, finished time.Time) {
+	for _, observer := range this {
+		observer.Observe(stage, article, started, finished)
+	}
+}
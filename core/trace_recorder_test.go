@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+func TestTraceRecorderFixture(t *testing.T) {
+	suite.Run(&TraceRecorderFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type TraceRecorderFixture struct {
+	*suite.T
+
+	origin   time.Time
+	recorder *TraceRecorder
+}
+
+func (this *TraceRecorderFixture) Setup() {
+	this.origin = Date(2022, 1, 1)
+	this.recorder = NewTraceRecorder(this.origin)
+}
+
+func (this *TraceRecorderFixture) at(micros int) time.Time {
+	return this.origin.Add(time.Duration(micros) * time.Microsecond)
+}
+
+func (this *TraceRecorderFixture) TestNoEvents() {
+	output, err := this.recorder.JSON()
+
+	this.So(err, should.BeNil)
+	this.So(string(output), should.Equal, `{"traceEvents":[],"displayTimeUnit":"ms"}`+"\n")
+}
+
+func (this *TraceRecorderFixture) TestEventsRecordedPerStageLane() {
+	reading := contracts.Stage{Index: 1, Name: "Reading"}
+	rendering := contracts.Stage{Index: 2, Name: "Rendering"}
+	article := &contracts.Article{
+		Source:   contracts.ArticleSource{Path: "a.md"},
+		Metadata: contracts.ArticleMetadata{Slug: "/a/"},
+	}
+	this.recorder.Observe(reading, article, this.at(1), this.at(3))
+	article.Error = errors.New("boink")
+	this.recorder.Observe(rendering, article, this.at(5), this.at(8))
+	this.recorder.Observe(rendering, nil, this.at(9), this.at(10))
+
+	output, err := this.recorder.JSON()
+
+	this.So(err, should.BeNil)
+	this.So(string(output), should.Equal, `{"traceEvents":[`+
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":1,"args":{"name":"Reading"}},`+
+		`{"name":"a.md","cat":"Reading","ph":"B","ts":1,"pid":1,"tid":1,"args":{"slug":"/a/"}},`+
+		`{"name":"a.md","cat":"Reading","ph":"E","ts":3,"pid":1,"tid":1},`+
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":2,"args":{"name":"Rendering"}},`+
+		`{"name":"a.md","cat":"Rendering","ph":"B","ts":5,"pid":1,"tid":2,"args":{"error":"boink","slug":"/a/"}},`+
+		`{"name":"a.md","cat":"Rendering","ph":"E","ts":8,"pid":1,"tid":2},`+
+		`{"name":"Finalize","cat":"Rendering","ph":"B","ts":9,"pid":1,"tid":2},`+
+		`{"name":"Finalize","cat":"Rendering","ph":"E","ts":10,"pid":1,"tid":2}`+
+		`],"displayTimeUnit":"ms"}`+"\n")
+}
+
+func (this *TraceRecorderFixture) TestObserversFanOut() {
+	a, b := NewFakeObserver(), NewFakeObserver()
+	stage := contracts.Stage{Index: 1, Name: "Stage"}
+
+	Observers{a, b}.Observe(stage, nil, this.at(0), this.at(1))
+
+	this.So(a.observations, should.Equal, []Observation{{Stage: stage, Elapsed: time.Microsecond}})
+	this.So(b.observations, should.Equal, a.observations)
+}
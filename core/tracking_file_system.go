@@ -0,0 +1,64 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// TrackingFileSystem decorates a contracts.FileSystem, remembering every
+// file written and directory created through it during a build.
+type TrackingFileSystem struct {
+	contracts.FileSystem
+
+	lock    sync.Mutex
+	written map[string]struct{}
+}
+
+func NewTrackingFileSystem(inner contracts.FileSystem) *TrackingFileSystem {
+	return &TrackingFileSystem{
+		FileSystem: inner,
+		written:    make(map[string]struct{}),
+	}
+}
+
+func (this *TrackingFileSystem) WriteFile(path string, content []byte, perm os.FileMode) error {
+	err := this.FileSystem.WriteFile(path, content, perm)
+	if err == nil {
+		this.track(path)
+	}
+	return err
+}
+func (this *TrackingFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	err := this.FileSystem.MkdirAll(path, perm)
+	if err == nil {
+		this.track(path)
+	}
+	return err
+}
+func (this *TrackingFileSystem) track(path string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.written[filepath.Clean(path)] = struct{}{}
+}
+
+// Produced lists (relative to root) every path written under root,
+// along with each of their parent directories.
+func (this *TrackingFileSystem) Produced(root string) map[string]struct{} {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	produced := make(map[string]struct{})
+	for path := range this.written {
+		rel, err := filepath.Rel(root, path)
+		if err != nil || hasPathTraversal(rel) {
+			continue
+		}
+		for ; rel != "."; rel = filepath.Dir(rel) {
+			produced[rel] = struct{}{}
+		}
+	}
+	return produced
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestTrackingFileSystemFixture(t *testing.T) {
+	suite.Run(&TrackingFileSystemFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type TrackingFileSystemFixture struct {
+	*suite.T
+
+	inner   *FakeFileSystem
+	tracker *TrackingFileSystem
+}
+
+func (this *TrackingFileSystemFixture) Setup() {
+	this.inner = NewFakeFileSystem()
+	this.tracker = NewTrackingFileSystem(this.inner)
+}
+
+func (this *TrackingFileSystemFixture) TestWritesPassedThroughAndTracked() {
+	_ = this.tracker.MkdirAll("rendered/a/b", 0755)
+	_ = this.tracker.WriteFile("rendered/a/b/index.html", []byte("B"), 0644)
+	_ = this.tracker.WriteFile("rendered/index.html", []byte("HOME"), 0644)
+	_ = this.tracker.WriteFile("elsewhere/index.html", []byte("ELSEWHERE"), 0644)
+
+	this.So(this.inner.Content("rendered/a/b/index.html"), should.Equal, "B")
+	this.So(this.tracker.Produced("rendered/"), should.Equal, map[string]struct{}{
+		"a":              {},
+		"a/b":            {},
+		"a/b/index.html": {},
+		"index.html":     {},
+	})
+}
+
+func (this *TrackingFileSystemFixture) TestFailedWritesNotTracked() {
+	this.inner.ErrWriteFile["rendered/index.html"] = errors.New("boink")
+	this.inner.ErrMkdirAll["rendered/a"] = errors.New("boink")
+
+	_ = this.tracker.MkdirAll("rendered/a", 0755)
+	_ = this.tracker.WriteFile("rendered/index.html", []byte("HOME"), 0644)
+
+	this.So(this.tracker.Produced("rendered"), should.BeEmpty)
+}
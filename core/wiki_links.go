@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// wikiLinks is a goldmark extension for [[slug]] and [[slug|label]]
+// links. They render as links to "wiki:slug", which LinkRewritingHandler
+// later resolves (once every article's slug is known).
+type wikiLinks struct{}
+
+func (wikiLinks) Extend(markdown goldmark.Markdown) {
+	markdown.Parser().AddOptions(parser.WithInlineParsers(util.Prioritized(wikiLinkParser{}, 199)))
+	markdown.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(wikiLinkRenderer{}, 199)))
+}
+
+const wikiLinkScheme = "wiki:"
+
+var kindWikiLink = ast.NewNodeKind("WikiLink")
+
+type wikiLink struct {
+	ast.BaseInline
+	Target []byte
+	Label  []byte
+}
+
+func (this *wikiLink) Kind() ast.NodeKind { return kindWikiLink }
+func (this *wikiLink) Dump(source []byte, level int) {
+	ast.DumpHelper(this, source, level, map[string]string{
+		"Target": string(this.Target),
+		"Label":  string(this.Label),
+	}, nil)
+}
+
+type wikiLinkParser struct{}
+
+func (wikiLinkParser) Trigger() []byte { return []byte{'['} }
+
+// Parse claims "[[...]]" (on a single line) and leaves anything else to
+// goldmark's own link parser.
+func (wikiLinkParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	if !bytes.HasPrefix(line, []byte("[[")) {
+		return nil
+	}
+	end := bytes.Index(line[2:], []byte("]]"))
+	if end < 0 {
+		return nil
+	}
+	content := line[2 : 2+end]
+	if bytes.ContainsAny(content, "[]") {
+		return nil
+	}
+	target, label, labeled := bytes.Cut(content, []byte("|"))
+	target = bytes.TrimSpace(target)
+	label = bytes.TrimSpace(label)
+	if len(target) == 0 {
+		return nil
+	}
+	if !labeled || len(label) == 0 {
+		label = target
+	}
+	block.Advance(2 + end + 2)
+	return &wikiLink{Target: bytes.Clone(target), Label: bytes.Clone(label)}
+}
+
+type wikiLinkRenderer struct{}
+
+func (wikiLinkRenderer) RegisterFuncs(registerer renderer.NodeRendererFuncRegisterer) {
+	registerer.Register(kindWikiLink, renderWikiLink)
+}
+
+func renderWikiLink(writer util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	link := node.(*wikiLink)
+	_, _ = writer.WriteString(`<a href="`)
+	_, _ = writer.Write(util.EscapeHTML(util.URLEscape(append([]byte(wikiLinkScheme), link.Target...), true)))
+	_, _ = writer.WriteString(`">`)
+	_, _ = writer.Write(util.EscapeHTML(link.Label))
+	_, _ = writer.WriteString(`</a>`)
+	return ast.WalkSkipChildren, nil
+}
@@ -19,6 +19,12 @@ func (Disk) WriteFile(path string, content []byte, perm os.FileMode) error {
 func (Disk) MkdirAll(path string, perm os.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
+func (Disk) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+func (Disk) Rename(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
 func (Disk) Walk(root string) (result chan contracts.FileSystemEntry) {
 	result = make(chan contracts.FileSystemEntry)
 	go func() {
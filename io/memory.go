@@ -0,0 +1,265 @@
+package io
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mdw-tools/hugoinho/contracts"
+)
+
+// Memory is a contracts.FileSystem held entirely in memory. It mirrors the
+// semantics of Disk closely enough (parent directories must exist before
+// writing, Walk visits entries in lexical order) that the pipeline can't
+// tell the difference.
+type Memory struct {
+	lock    sync.RWMutex
+	entries map[string]*memoryEntry
+
+	// contents indexes the names of the entries in each directory, so that
+	// Walk needn't scan every entry for each directory it visits.
+	contents map[string]map[string]struct{}
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		entries:  make(map[string]*memoryEntry),
+		contents: make(map[string]map[string]struct{}),
+	}
+}
+
+func (this *Memory) ReadFile(path string) ([]byte, error) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	entry, found := this.entries[clean(path)]
+	if !found {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	if entry.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: path, Err: errIsDirectory}
+	}
+	return slices.Clone(entry.content), nil
+}
+func (this *Memory) WriteFile(path string, content []byte, perm os.FileMode) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	path = clean(path)
+	if !this.isDir(filepath.Dir(path)) {
+		return &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	if existing, found := this.entries[path]; found && existing.IsDir() {
+		return &fs.PathError{Op: "open", Path: path, Err: errIsDirectory}
+	}
+	this.put(path, &memoryEntry{
+		name:    filepath.Base(path),
+		mode:    perm.Perm(),
+		modTime: time.Now(),
+		content: slices.Clone(content),
+	})
+	return nil
+}
+func (this *Memory) MkdirAll(path string, perm os.FileMode) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	path = clean(path)
+	for _, dir := range ancestry(path) {
+		existing, found := this.entries[dir]
+		if found && !existing.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: errNotDirectory}
+		}
+		if found {
+			continue
+		}
+		this.put(dir, &memoryEntry{
+			name:    filepath.Base(dir),
+			mode:    fs.ModeDir | perm.Perm(),
+			modTime: time.Now(),
+		})
+	}
+	return nil
+}
+func (this *Memory) RemoveAll(path string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	path = clean(path)
+	for existing := range this.entries {
+		if within(existing, path) {
+			this.drop(existing)
+		}
+	}
+	return nil
+}
+func (this *Memory) Rename(oldPath, newPath string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	oldPath, newPath = clean(oldPath), clean(newPath)
+	if _, found := this.entries[oldPath]; !found {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrNotExist}
+	}
+	if !this.isDir(filepath.Dir(newPath)) {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrNotExist}
+	}
+	if oldPath == newPath {
+		return nil
+	}
+	if within(newPath, oldPath) {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: fs.ErrInvalid}
+	}
+	if len(this.children(newPath)) > 0 {
+		return &os.LinkError{Op: "rename", Old: oldPath, New: newPath, Err: errNotEmpty}
+	}
+	moved := make(map[string]*memoryEntry)
+	for existing, entry := range this.entries {
+		if within(existing, oldPath) {
+			moved[newPath+strings.TrimPrefix(existing, oldPath)] = entry
+			this.drop(existing)
+		}
+	}
+	moved[newPath].name = filepath.Base(newPath)
+	for path, entry := range moved {
+		this.put(path, entry)
+	}
+	return nil
+}
+func (this *Memory) Walk(root string) (result chan contracts.FileSystemEntry) {
+	result = make(chan contracts.FileSystemEntry)
+	go func() {
+		defer close(result)
+		for _, entry := range this.snapshot(root) {
+			result <- entry
+			if entry.Error != nil {
+				return
+			}
+		}
+	}()
+	return result
+}
+
+// Stat reports the file or directory found at path, or an error
+// satisfying errors.Is(err, fs.ErrNotExist).
+func (this *Memory) Stat(path string) (fs.FileInfo, error) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	path = clean(path)
+	if isRoot(path) {
+		return &memoryEntry{name: path, mode: fs.ModeDir | 0755}, nil
+	}
+	entry, found := this.entries[path]
+	if !found {
+		return nil, &fs.PathError{Op: "stat", Path: path, Err: fs.ErrNotExist}
+	}
+	return entry, nil
+}
+
+// snapshot gathers the entries under root (in the order filepath.WalkDir
+// would visit them) so that the lock isn't held while a slow consumer
+// drains the Walk channel.
+func (this *Memory) snapshot(root string) (entries []contracts.FileSystemEntry) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	cleaned := clean(root)
+	entry, found := this.entries[cleaned]
+	if isRoot(cleaned) {
+		entry, found = &memoryEntry{name: cleaned, mode: fs.ModeDir | 0755}, true
+	}
+	if !found {
+		return []contracts.FileSystemEntry{{
+			Root:  root,
+			Path:  root,
+			Error: &fs.PathError{Op: "lstat", Path: root, Err: fs.ErrNotExist},
+		}}
+	}
+	return this.visit(root, root, entry, entries)
+}
+func (this *Memory) visit(root, path string, entry *memoryEntry, entries []contracts.FileSystemEntry) []contracts.FileSystemEntry {
+	entries = append(entries, contracts.FileSystemEntry{Root: root, Path: path, DirEntry: entry})
+	if !entry.IsDir() {
+		return entries
+	}
+	for _, name := range this.children(clean(path)) {
+		child := filepath.Join(path, name)
+		entries = this.visit(root, child, this.entries[clean(child)], entries)
+	}
+	return entries
+}
+func (this *Memory) children(dir string) (names []string) {
+	return slices.Sorted(maps.Keys(this.contents[dir]))
+}
+func (this *Memory) put(path string, entry *memoryEntry) {
+	this.entries[path] = entry
+	parent := filepath.Dir(path)
+	if this.contents[parent] == nil {
+		this.contents[parent] = make(map[string]struct{})
+	}
+	this.contents[parent][filepath.Base(path)] = struct{}{}
+}
+func (this *Memory) drop(path string) {
+	delete(this.entries, path)
+	delete(this.contents, path)
+	parent := filepath.Dir(path)
+	delete(this.contents[parent], filepath.Base(path))
+	if len(this.contents[parent]) == 0 {
+		delete(this.contents, parent)
+	}
+}
+func (this *Memory) isDir(path string) bool {
+	if isRoot(path) {
+		return true
+	}
+	entry, found := this.entries[path]
+	return found && entry.IsDir()
+}
+
+func clean(path string) string { return filepath.Clean(path) }
+func isRoot(path string) bool  { return path == "." || path == string(filepath.Separator) }
+func within(path, dir string) bool {
+	if isRoot(dir) {
+		return true
+	}
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+func ancestry(path string) []string {
+	var dirs []string
+	for dir := path; !isRoot(dir); dir = filepath.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	slices.Reverse(dirs)
+	return dirs
+}
+
+var (
+	errIsDirectory  = errors.New("is a directory")
+	errNotDirectory = errors.New("not a directory")
+	errNotEmpty     = errors.New("directory not empty")
+)
+
+///////////////////////////////////////////////////////////////////
+
+type memoryEntry struct {
+	name    string
+	mode    fs.FileMode
+	modTime time.Time
+	content []byte
+}
+
+func (this *memoryEntry) Name() string               { return this.name }
+func (this *memoryEntry) Size() int64                { return int64(len(this.content)) }
+func (this *memoryEntry) Mode() fs.FileMode          { return this.mode }
+func (this *memoryEntry) ModTime() time.Time         { return this.modTime }
+func (this *memoryEntry) IsDir() bool                { return this.mode.IsDir() }
+func (this *memoryEntry) Sys() any                   { return nil }
+func (this *memoryEntry) Type() fs.FileMode          { return this.mode.Type() }
+func (this *memoryEntry) Info() (fs.FileInfo, error) { return this, nil }
@@ -0,0 +1,158 @@
+package io
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/mdw-go/testing/v2/should"
+	"github.com/mdw-go/testing/v2/suite"
+)
+
+func TestMemoryFixture(t *testing.T) {
+	suite.Run(&MemoryFixture{T: suite.New(t)}, suite.Options.UnitTests())
+}
+
+type MemoryFixture struct {
+	*suite.T
+	memory *Memory
+}
+
+func (this *MemoryFixture) Setup() {
+	this.memory = NewMemory()
+}
+
+func (this *MemoryFixture) walk(root string) (paths []string) {
+	for entry := range this.memory.Walk(root) {
+		this.So(entry.Error, should.BeNil)
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+func (this *MemoryFixture) TestWriteThenRead() {
+	this.So(this.memory.MkdirAll("rendered/a", 0755), should.BeNil)
+	this.So(this.memory.WriteFile("rendered/a/index.html", []byte("A"), 0644), should.BeNil)
+
+	content, err := this.memory.ReadFile("rendered/a/index.html")
+
+	this.So(err, should.BeNil)
+	this.So(string(content), should.Equal, "A")
+}
+
+func (this *MemoryFixture) TestReadMissingFile_NotExist() {
+	content, err := this.memory.ReadFile("missing")
+
+	this.So(errors.Is(err, fs.ErrNotExist), should.BeTrue)
+	this.So(content, should.BeNil)
+}
+
+func (this *MemoryFixture) TestWriteWithoutParent_NotExist() {
+	err := this.memory.WriteFile("rendered/a/index.html", []byte("A"), 0644)
+
+	this.So(errors.Is(err, fs.ErrNotExist), should.BeTrue)
+}
+
+func (this *MemoryFixture) TestMkdirAllThroughFile_Err() {
+	_ = this.memory.WriteFile("file", []byte("A"), 0644)
+
+	err := this.memory.MkdirAll("file/folder", 0755)
+
+	this.So(err, should.NOT.BeNil)
+}
+
+func (this *MemoryFixture) TestStat() {
+	_ = this.memory.MkdirAll("rendered/a", 0755)
+	_ = this.memory.WriteFile("rendered/a/index.html", []byte("AAA"), 0644)
+
+	dir, dirErr := this.memory.Stat("rendered/a/")
+	file, fileErr := this.memory.Stat("rendered/a/index.html")
+	_, missingErr := this.memory.Stat("rendered/b")
+
+	this.So(dirErr, should.BeNil)
+	this.So(dir.IsDir(), should.BeTrue)
+	this.So(fileErr, should.BeNil)
+	this.So(file.IsDir(), should.BeFalse)
+	this.So(file.Size(), should.Equal, int64(3))
+	this.So(errors.Is(missingErr, fs.ErrNotExist), should.BeTrue)
+}
+
+func (this *MemoryFixture) TestWalkVisitsEntriesInLexicalOrder() {
+	_ = this.memory.MkdirAll("root/a", 0755)
+	_ = this.memory.MkdirAll("root/a-b", 0755)
+	_ = this.memory.WriteFile("root/a/index.html", nil, 0644)
+	_ = this.memory.WriteFile("root/a-b/index.html", nil, 0644)
+	_ = this.memory.WriteFile("outside.html", nil, 0644)
+
+	this.So(this.walk("root"), should.Equal, []string{
+		"root",
+		"root/a",
+		"root/a/index.html",
+		"root/a-b",
+		"root/a-b/index.html",
+	})
+}
+
+func (this *MemoryFixture) TestWalkMissingRoot_Err() {
+	var entries int
+	var err error
+	for entry := range this.memory.Walk("missing") {
+		entries++
+		err = entry.Error
+	}
+
+	this.So(entries, should.Equal, 1)
+	this.So(errors.Is(err, fs.ErrNotExist), should.BeTrue)
+}
+
+func (this *MemoryFixture) TestRemoveAll() {
+	_ = this.memory.MkdirAll("root/a", 0755)
+	_ = this.memory.WriteFile("root/a/index.html", nil, 0644)
+	_ = this.memory.WriteFile("root-sibling.html", nil, 0644)
+
+	this.So(this.memory.RemoveAll("root"), should.BeNil)
+	this.So(this.memory.RemoveAll("missing"), should.BeNil)
+
+	this.So(this.walk("."), should.Equal, []string{".", "root-sibling.html"})
+}
+
+func (this *MemoryFixture) TestRenameMovesDirectoryTree() {
+	_ = this.memory.MkdirAll("staging/a", 0755)
+	_ = this.memory.WriteFile("staging/a/index.html", []byte("A"), 0644)
+
+	err := this.memory.Rename("staging", "live")
+
+	this.So(err, should.BeNil)
+	this.So(this.walk("."), should.Equal, []string{".", "live", "live/a", "live/a/index.html"})
+	info, _ := this.memory.Stat("live")
+	this.So(info.Name(), should.Equal, "live")
+}
+
+func (this *MemoryFixture) TestWalkAfterRemovingAndRenaming() {
+	_ = this.memory.MkdirAll("live/a", 0755)
+	_ = this.memory.WriteFile("live/a/index.html", nil, 0644)
+	_ = this.memory.MkdirAll("staging/b", 0755)
+	_ = this.memory.WriteFile("staging/b/index.html", nil, 0644)
+
+	this.So(this.memory.RemoveAll("live"), should.BeNil)
+	this.So(this.memory.Rename("staging", "live"), should.BeNil)
+	_ = this.memory.MkdirAll("live/c", 0755)
+
+	this.So(this.walk("."), should.Equal, []string{".", "live", "live/b", "live/b/index.html", "live/c"})
+}
+
+func (this *MemoryFixture) TestRenameMissing_NotExist() {
+	err := this.memory.Rename("missing", "live")
+
+	this.So(errors.Is(err, fs.ErrNotExist), should.BeTrue)
+}
+
+func (this *MemoryFixture) TestRenameOntoNonEmptyDirectory_Err() {
+	_ = this.memory.MkdirAll("staging", 0755)
+	_ = this.memory.MkdirAll("live/a", 0755)
+
+	err := this.memory.Rename("staging", "live")
+
+	this.So(err, should.NOT.BeNil)
+	this.So(this.walk("live"), should.Equal, []string{"live", "live/a"})
+}